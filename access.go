@@ -0,0 +1,253 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/antoniobuconjic/directusapi/access"
+)
+
+// AccessConfig is a declarative access control of the instance, roles with their permissions.
+//
+// Deprecated: use access.Config.
+type AccessConfig = access.Config
+
+// RoleConfig is a role of AccessConfig identified by its name.
+//
+// Deprecated: use access.Role.
+type RoleConfig = access.Role
+
+// PermissionConfig is a permission of a role identified by its collection and action.
+//
+// Deprecated: use access.Permission.
+type PermissionConfig = access.Permission
+
+// AccessChange is a change of the instance made by ApplyAccess.
+//
+// Deprecated: use access.Change.
+type AccessChange = access.Change
+
+// ApplyAccessOptions controls ApplyAccess
+type ApplyAccessOptions struct {
+	// DryRun returns changes without making them, e.g. for a review of a pull request
+	DryRun bool
+	// PruneRoles deletes roles missing in the config including the administrator role, they are kept
+	// by default because deleting a role removes access of its users
+	PruneRoles bool
+}
+
+// ExportAccess returns roles and permissions of the instance as access.Config sorted by names,
+// so exports of the same access control are equal. It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/roles.html
+// https://docs.directus.io/reference/system/permissions.html
+func (d API[R, W, PK]) ExportAccess(ctx context.Context) (access.Config, error) {
+	if d.Version == V8 {
+		return access.Config{}, errors.New("export access is supported only by v9")
+	}
+	roles, perms, err := d.liveAccess(ctx)
+	if err != nil {
+		return access.Config{}, err
+	}
+	var cfg access.Config
+	byRole := map[string][]access.Permission{}
+	for _, p := range perms {
+		byRole[p.Role] = append(byRole[p.Role], permissionConfig(p))
+	}
+	for _, r := range roles {
+		rc := roleConfig(r)
+		rc.Permissions = byRole[r.ID]
+		cfg.Roles = append(cfg.Roles, rc)
+	}
+	cfg.Public = byRole[""]
+	cfg.Sort()
+	return cfg, nil
+}
+
+// ApplyAccess reconciles roles and permissions of the instance to match cfg and returns the changes.
+// Roles are matched by name, permissions by their role, collection and action. Permissions of roles in cfg
+// which are missing in cfg are deleted, other roles are deleted only by PruneRoles. It's supported only by v9.
+func (d API[R, W, PK]) ApplyAccess(ctx context.Context, cfg access.Config, opts ApplyAccessOptions) ([]access.Change, error) {
+	if d.Version == V8 {
+		return nil, errors.New("apply access is supported only by v9")
+	}
+	roles, perms, err := d.liveAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	liveRoles := map[string]Role{}
+	roleNames := map[string]string{"": ""}
+	for _, r := range roles {
+		liveRoles[r.Name] = r
+		roleNames[r.ID] = r.Name
+	}
+	type permissionKey struct{ role, collection, action string }
+	livePerms := map[permissionKey]Permission{}
+	for _, p := range perms {
+		livePerms[permissionKey{roleNames[p.Role], p.Collection, p.Action}] = p
+	}
+
+	var changes []access.Change
+	apply := func(change access.Change, method, path string, body any, dest any) error {
+		changes = append(changes, change)
+		if opts.DryRun {
+			return nil
+		}
+		if err := d.provisionRequest(ctx, method, path, None(), body, dest); err != nil {
+			return fmt.Errorf("%s: %w", change, err)
+		}
+		return nil
+	}
+
+	wanted := map[string][]access.Permission{"": cfg.Public}
+	roleIDs := map[string]string{"": ""}
+	for _, rc := range cfg.Roles {
+		if _, dup := wanted[rc.Name]; dup || rc.Name == "" {
+			return changes, fmt.Errorf("role name %q is empty or not unique", rc.Name)
+		}
+		wanted[rc.Name] = rc.Permissions
+		live, ok := liveRoles[rc.Name]
+		if !ok {
+			var created itemEnvelope[Role]
+			if err := apply(access.Change{Action: "create", Collection: CollectionRoles, Name: rc.Name}, http.MethodPost, "/roles", roleWrite(rc), &created); err != nil {
+				return changes, err
+			}
+			roleIDs[rc.Name] = created.Data.ID
+			continue
+		}
+		roleIDs[rc.Name] = live.ID
+		if !sameJSON(roleWithoutPermissions(rc), roleConfig(live)) {
+			if err := apply(access.Change{Action: "update", Collection: CollectionRoles, Name: rc.Name}, http.MethodPatch, "/roles/"+url.PathEscape(live.ID), roleWrite(rc), nil); err != nil {
+				return changes, err
+			}
+		}
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, pc := range wanted[name] {
+			key := permissionKey{name, pc.Collection, pc.Action}
+			change := access.Change{Collection: CollectionPermissions, Name: name + "/" + pc.Collection + "/" + pc.Action}
+			live, ok := livePerms[key]
+			delete(livePerms, key)
+			switch {
+			case !ok:
+				change.Action = "create"
+				err = apply(change, http.MethodPost, "/permissions", permissionWrite(pc, roleIDs[name]), nil)
+			case !sameJSON(pc, permissionConfig(live)):
+				change.Action = "update"
+				err = apply(change, http.MethodPatch, fmt.Sprintf("/permissions/%d", live.ID), permissionWrite(pc, roleIDs[name]), nil)
+			}
+			if err != nil {
+				return changes, err
+			}
+		}
+	}
+
+	var stale []permissionKey
+	for key := range livePerms {
+		// permissions of roles missing in cfg are kept with the role or deleted with it by PruneRoles
+		if _, ok := wanted[key.role]; ok {
+			stale = append(stale, key)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		a, b := stale[i], stale[j]
+		return a.role+"/"+a.collection+"/"+a.action < b.role+"/"+b.collection+"/"+b.action
+	})
+	for _, key := range stale {
+		change := access.Change{Action: "delete", Collection: CollectionPermissions, Name: key.role + "/" + key.collection + "/" + key.action}
+		if err := apply(change, http.MethodDelete, fmt.Sprintf("/permissions/%d", livePerms[key].ID), nil, nil); err != nil {
+			return changes, err
+		}
+	}
+
+	if opts.PruneRoles {
+		for _, r := range roles {
+			if _, ok := wanted[r.Name]; ok {
+				continue
+			}
+			if err := apply(access.Change{Action: "delete", Collection: CollectionRoles, Name: r.Name}, http.MethodDelete, "/roles/"+url.PathEscape(r.ID), nil, nil); err != nil {
+				return changes, err
+			}
+		}
+	}
+	return changes, nil
+}
+
+// liveAccess returns roles sorted by name and all permissions of the instance
+func (d API[R, W, PK]) liveAccess(ctx context.Context) ([]Role, []Permission, error) {
+	var roles struct {
+		Data []Role `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, "/roles", SortAsc("name").Limit(AllItems), nil, &roles); err != nil {
+		return nil, nil, fmt.Errorf("execute roles request: %w", err)
+	}
+	var perms struct {
+		Data []Permission `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, "/permissions", Limit(AllItems), nil, &perms); err != nil {
+		return nil, nil, fmt.Errorf("execute permissions request: %w", err)
+	}
+	return roles.Data, perms.Data, nil
+}
+
+func roleConfig(r Role) access.Role {
+	return access.Role{
+		Name:        r.Name,
+		Icon:        r.Icon,
+		Description: r.Description,
+		IPAccess:    r.IPAccess,
+		EnforceTFA:  r.EnforceTFA,
+		AdminAccess: r.AdminAccess,
+		AppAccess:   r.AppAccess,
+	}
+}
+
+func roleWithoutPermissions(r access.Role) access.Role {
+	r.Permissions = nil
+	return r
+}
+
+func roleWrite(r access.Role) RoleW {
+	return RoleW{r.Name, r.Icon, r.Description, r.IPAccess, r.EnforceTFA, r.AdminAccess, r.AppAccess}
+}
+
+func permissionConfig(p Permission) access.Permission {
+	return access.Permission{
+		Collection:  p.Collection,
+		Action:      p.Action,
+		Permissions: p.Permissions,
+		Validation:  p.Validation,
+		Presets:     p.Presets,
+		Fields:      p.Fields,
+	}
+}
+
+// write returns the permission of the role, an empty role is the public role
+func permissionWrite(p access.Permission, roleID string) PermissionW {
+	role := UnsetOptional[string]()
+	if roleID != "" {
+		role = SetOptional(roleID)
+	}
+	return PermissionW{role, p.Collection, p.Action, p.Permissions, p.Validation, p.Presets, p.Fields}
+}
+
+// sameJSON reports whether a and b are encoded equally, so numbers of configs
+// and of decoded responses are compared by their values
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
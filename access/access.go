@@ -0,0 +1,71 @@
+// Package access is a declarative access control of a Directus instance, roles with their permissions,
+// e.g. kept in a repository as JSON or YAML, exported and applied by API.ExportAccess and API.ApplyAccess.
+package access
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Config is the access control of an instance.
+// Structs have yaml tags of the same names, so YAML libraries decode it without conversion.
+type Config struct {
+	Roles []Role `json:"roles" yaml:"roles"`
+	// Public are permissions of the public role
+	Public []Permission `json:"public,omitempty" yaml:"public,omitempty"`
+}
+
+// Role is a role of Config identified by its name
+type Role struct {
+	Name        string       `json:"name" yaml:"name"`
+	Icon        string       `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	IPAccess    []string     `json:"ip_access,omitempty" yaml:"ip_access,omitempty"`
+	EnforceTFA  bool         `json:"enforce_tfa,omitempty" yaml:"enforce_tfa,omitempty"`
+	AdminAccess bool         `json:"admin_access,omitempty" yaml:"admin_access,omitempty"`
+	AppAccess   bool         `json:"app_access,omitempty" yaml:"app_access,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+// Permission is a permission of a role identified by its collection and action
+type Permission struct {
+	Collection  string         `json:"collection" yaml:"collection"`
+	Action      string         `json:"action" yaml:"action"`
+	Permissions map[string]any `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Validation  map[string]any `json:"validation,omitempty" yaml:"validation,omitempty"`
+	Presets     map[string]any `json:"presets,omitempty" yaml:"presets,omitempty"`
+	Fields      []string       `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Change is a change of the instance made by applying a Config
+type Change struct {
+	// Action is create, update or delete
+	Action string
+	// Collection is directus_roles or directus_permissions
+	Collection string
+	// Name is the role name, permissions are named role/collection/action, the public role is empty
+	Name string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s", c.Action, c.Collection, c.Name)
+}
+
+// Sort sorts roles by names and their permissions by collections and actions,
+// so configs of the same access control are equal
+func (c *Config) Sort() {
+	sort.Slice(c.Roles, func(i, j int) bool { return c.Roles[i].Name < c.Roles[j].Name })
+	for _, r := range c.Roles {
+		sortPermissions(r.Permissions)
+	}
+	sortPermissions(c.Public)
+}
+
+func sortPermissions(perms []Permission) {
+	sort.Slice(perms, func(i, j int) bool {
+		if perms[i].Collection != perms[j].Collection {
+			return perms[i].Collection < perms[j].Collection
+		}
+		return perms[i].Action < perms[j].Action
+	})
+}
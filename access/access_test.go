@@ -0,0 +1,34 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigSort(t *testing.T) {
+	cfg := Config{
+		Roles: []Role{
+			{Name: "Editors", Permissions: []Permission{
+				{Collection: "pages", Action: "read"},
+				{Collection: "articles", Action: "update"},
+				{Collection: "articles", Action: "read"},
+			}},
+			{Name: "Authors"},
+		},
+		Public: []Permission{{Collection: "pages", Action: "read"}, {Collection: "articles", Action: "read"}},
+	}
+	cfg.Sort()
+	assert.Equal(t, Config{
+		Roles: []Role{
+			{Name: "Authors"},
+			{Name: "Editors", Permissions: []Permission{
+				{Collection: "articles", Action: "read"},
+				{Collection: "articles", Action: "update"},
+				{Collection: "pages", Action: "read"},
+			}},
+		},
+		Public: []Permission{{Collection: "articles", Action: "read"}, {Collection: "pages", Action: "read"}},
+	}, cfg)
+	assert.Equal(t, "delete directus_roles Old", Change{"delete", "directus_roles", "Old"}.String())
+}
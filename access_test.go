@@ -0,0 +1,165 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antoniobuconjic/directusapi/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accessServer keeps roles and permissions in memory
+type accessServer struct {
+	mu     sync.Mutex
+	roles  map[string]map[string]any
+	perms  map[int]map[string]any
+	nextID int
+	writes int
+}
+
+func (s *accessServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/_/"), "/")
+	var body map[string]any
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if r.Method != http.MethodGet {
+		s.writes++
+	}
+	s.nextID++
+	switch r.Method + " " + segments[0] {
+	case "GET roles":
+		list := []map[string]any{}
+		for _, role := range s.roles {
+			list = append(list, role)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": list})
+	case "GET permissions":
+		list := []map[string]any{}
+		for _, p := range s.perms {
+			list = append(list, p)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": list})
+	case "POST roles":
+		body["id"] = "role" + strconv.Itoa(s.nextID)
+		s.roles[body["id"].(string)] = body
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": body})
+	case "PATCH roles":
+		for k, v := range body {
+			s.roles[segments[1]][k] = v
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": s.roles[segments[1]]})
+	case "DELETE roles":
+		delete(s.roles, segments[1])
+		w.WriteHeader(http.StatusNoContent)
+	case "POST permissions":
+		body["id"] = s.nextID
+		s.perms[s.nextID] = body
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": body})
+	case "PATCH permissions":
+		id, _ := strconv.Atoi(segments[1])
+		for k, v := range body {
+			s.perms[id][k] = v
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": s.perms[id]})
+	case "DELETE permissions":
+		id, _ := strconv.Atoi(segments[1])
+		delete(s.perms, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestApplyAccess(t *testing.T) {
+	srv := &accessServer{
+		roles: map[string]map[string]any{
+			"admin": {"id": "admin", "name": "Administrator", "admin_access": true, "app_access": true},
+			"old":   {"id": "old", "name": "Old"},
+		},
+		perms: map[int]map[string]any{
+			1: {"id": 1, "role": "old", "collection": "articles", "action": "read"},
+			2: {"id": 2, "role": nil, "collection": "articles", "action": "create"},
+		},
+		nextID: 10,
+	}
+	api := newTestAPI(t, srv)
+	api.Version = V9
+
+	cfg := access.Config{
+		Roles: []access.Role{
+			{Name: "Administrator", AdminAccess: true, AppAccess: true},
+			{Name: "Old", Permissions: []access.Permission{
+				{Collection: "articles", Action: "read", Permissions: map[string]any{"status": map[string]any{"_eq": "published"}}},
+			}},
+			{Name: "Editors", AppAccess: true, Permissions: []access.Permission{
+				{Collection: "articles", Action: "update", Fields: []string{"title"}},
+				{Collection: "articles", Action: "read", Fields: []string{"*"}},
+			}},
+		},
+		Public: []access.Permission{{Collection: "articles", Action: "read"}},
+	}
+
+	changes, err := api.ApplyAccess(context.Background(), cfg, ApplyAccessOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Zero(t, srv.writes, "dry run should not change anything")
+	var planned []string
+	for _, c := range changes {
+		planned = append(planned, c.String())
+	}
+	assert.Equal(t, []string{
+		"create directus_roles Editors",
+		"create directus_permissions /articles/read",
+		"create directus_permissions Editors/articles/update",
+		"create directus_permissions Editors/articles/read",
+		"update directus_permissions Old/articles/read",
+		"delete directus_permissions /articles/create",
+	}, planned)
+
+	applied, err := api.ApplyAccess(context.Background(), cfg, ApplyAccessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, changes, applied)
+
+	exported, err := api.ExportAccess(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exported.Roles, 3)
+	assert.Equal(t, "Administrator", exported.Roles[0].Name)
+	assert.Equal(t, []access.Permission{
+		{Collection: "articles", Action: "read", Fields: []string{"*"}},
+		{Collection: "articles", Action: "update", Fields: []string{"title"}},
+	}, exported.Roles[1].Permissions)
+	assert.Equal(t, []access.Permission{{Collection: "articles", Action: "read"}}, exported.Public)
+
+	// the exported config matches the instance
+	changes, err = api.ApplyAccess(context.Background(), exported, ApplyAccessOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	changes, err = api.ApplyAccess(context.Background(), cfg, ApplyAccessOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// roles missing in the config keep their permissions without pruning
+	exported.Roles = exported.Roles[:2]
+	changes, err = api.ApplyAccess(context.Background(), exported, ApplyAccessOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = api.ApplyAccess(context.Background(), exported, ApplyAccessOptions{PruneRoles: true})
+	require.NoError(t, err)
+	assert.Equal(t, []access.Change{{Action: "delete", Collection: CollectionRoles, Name: "Old"}}, changes)
+	assert.Len(t, srv.roles, 2)
+
+	_, err = api.ApplyAccess(context.Background(), access.Config{Roles: []access.Role{{Name: "A"}, {Name: "A"}}}, ApplyAccessOptions{})
+	assert.Error(t, err)
+}
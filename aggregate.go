@@ -0,0 +1,187 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Aggregation lists fields of aggregate functions computed by the server,
+// use "*" in Count to count the items themselves
+type Aggregation struct {
+	Count         []string
+	CountDistinct []string
+	Sum           []string
+	SumDistinct   []string
+	Avg           []string
+	AvgDistinct   []string
+	Min           []string
+	Max           []string
+}
+
+func (a Aggregation) functions() map[string][]string {
+	return map[string][]string{
+		"count":         a.Count,
+		"countDistinct": a.CountDistinct,
+		"sum":           a.Sum,
+		"sumDistinct":   a.SumDistinct,
+		"avg":           a.Avg,
+		"avgDistinct":   a.AvgDistinct,
+		"min":           a.Min,
+		"max":           a.Max,
+	}
+}
+
+// params adds aggregate params of non-empty functions to qv, it returns false when there is none
+func (a Aggregation) params(qv map[string]string) bool {
+	found := false
+	for fn, fields := range a.functions() {
+		if len(fields) > 0 {
+			qv[fmt.Sprintf("aggregate[%s]", fn)] = strings.Join(fields, ",")
+			found = true
+		}
+	}
+	return found
+}
+
+// AggregateResult are values of aggregate functions keyed by the field,
+// values of fields without any items, e.g. an average of no items, are missing
+type AggregateResult struct {
+	Count         map[string]float64
+	CountDistinct map[string]float64
+	Sum           map[string]float64
+	SumDistinct   map[string]float64
+	Avg           map[string]float64
+	AvgDistinct   map[string]float64
+	Min           map[string]float64
+	Max           map[string]float64
+}
+
+func (r *AggregateResult) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	dests := map[string]*map[string]float64{
+		"count":         &r.Count,
+		"countDistinct": &r.CountDistinct,
+		"sum":           &r.Sum,
+		"sumDistinct":   &r.SumDistinct,
+		"avg":           &r.Avg,
+		"avgDistinct":   &r.AvgDistinct,
+		"min":           &r.Min,
+		"max":           &r.Max,
+	}
+	for fn, data := range raw {
+		dest, ok := dests[fn]
+		if !ok {
+			continue
+		}
+		values, err := aggregateValues(data)
+		if err != nil {
+			return fmt.Errorf("decode %s: %w", fn, err)
+		}
+		*dest = values
+	}
+	return nil
+}
+
+// aggregateValues decodes values of a function keyed by the field,
+// a count of all items is a plain value which is keyed by "*"
+func aggregateValues(data json.RawMessage) (map[string]float64, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		fields = map[string]json.RawMessage{"*": data}
+	}
+	out := make(map[string]float64, len(fields))
+	for field, raw := range fields {
+		// numbers of some databases are strings, functions of no items are null
+		s := strings.Trim(string(raw), `"`)
+		if s == "null" {
+			continue
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value of %s: %w", field, err)
+		}
+		out[field] = v
+	}
+	return out, nil
+}
+
+// AggregateGroup is a row of AggregateGroups, Group holds values of the grouped fields
+// keyed as returned by the server
+type AggregateGroup struct {
+	Group map[string]any
+	AggregateResult
+}
+
+func (g *AggregateGroup) UnmarshalJSON(b []byte) error {
+	if err := g.AggregateResult.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for fn := range (Aggregation{}).functions() {
+		delete(raw, fn)
+	}
+	g.Group = raw
+	return nil
+}
+
+// Aggregate computes aggregate functions of items matching filters of q on the server,
+// e.g. Aggregation{Sum: []string{"price"}} of orders instead of fetching all of them.
+// It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#aggregation-grouping
+func (d API[R, W, PK]) Aggregate(ctx context.Context, a Aggregation, q Query) (AggregateResult, error) {
+	groups, err := d.aggregate(ctx, a, nil, q)
+	if err != nil || len(groups) == 0 {
+		return AggregateResult{}, err
+	}
+	return groups[0].AggregateResult, nil
+}
+
+// AggregateGroups computes aggregate functions like Aggregate for every group of items with the same
+// values of groupBy fields, fields can be wrapped in date functions, e.g. year(date_created).
+// It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#aggregation-grouping
+func (d API[R, W, PK]) AggregateGroups(ctx context.Context, a Aggregation, groupBy []string, q Query) ([]AggregateGroup, error) {
+	if len(groupBy) == 0 {
+		return nil, errors.New("groups without any field")
+	}
+	return d.aggregate(ctx, a, groupBy, q)
+}
+
+func (d API[R, W, PK]) aggregate(ctx context.Context, a Aggregation, groupBy []string, q Query) ([]AggregateGroup, error) {
+	if d.Version == V8 {
+		return nil, errors.New("aggregate is supported only by v9")
+	}
+	req, err := d.itemsRequest(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	delete(req.qv, "fields")
+	if !a.params(req.qv) {
+		return nil, errors.New("aggregation without any function")
+	}
+	if len(groupBy) > 0 {
+		req.qv["groupBy"] = strings.Join(groupBy, ",")
+	}
+	var respBody struct {
+		Data []AggregateGroup `json:"data"`
+	}
+	if err := d.executeRequest(req, http.StatusOK, &respBody); err != nil {
+		return nil, fmt.Errorf("execute aggregate request: %w", err)
+	}
+	return respBody.Data, nil
+}
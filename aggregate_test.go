@@ -0,0 +1,71 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "*", q.Get("aggregate[count]"))
+		assert.Equal(t, "weight,price", q.Get("aggregate[sum]"))
+		assert.Equal(t, "price", q.Get("aggregate[avg]"))
+		assert.Equal(t, "red", q.Get("filter[color][_eq]"))
+		assert.False(t, q.Has("fields"))
+		_, _ = w.Write([]byte(`{"data":[{"count":"4","sum":{"weight":10,"price":"12.5"},"avg":{"price":null}}]}`))
+	}))
+	api.Version = V9
+
+	res, err := api.Aggregate(context.Background(), Aggregation{
+		Count: []string{"*"},
+		Sum:   []string{"weight", "price"},
+		Avg:   []string{"price"},
+	}, Eq("color", "red"))
+	require.NoError(t, err)
+	assert.Equal(t, AggregateResult{
+		Count: map[string]float64{"*": 4},
+		Sum:   map[string]float64{"weight": 10, "price": 12.5},
+		Avg:   map[string]float64{},
+	}, res)
+
+	_, err = api.Aggregate(context.Background(), Aggregation{}, None())
+	assert.Error(t, err)
+	api.Version = V8
+	_, err = api.Aggregate(context.Background(), Aggregation{Count: []string{"*"}}, None())
+	assert.EqualError(t, err, "aggregate is supported only by v9")
+}
+
+func TestAggregateGroups(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "color,year(date_created)", r.URL.Query().Get("groupBy"))
+		_, _ = w.Write([]byte(`{"data":[
+			{"color":"red","date_created_year":2023,"count":"2","avg":{"price":1.5}},
+			{"color":null,"date_created_year":2024,"count":1,"avg":{"price":"3"}}
+		]}`))
+	}))
+	api.Version = V9
+
+	groups, err := api.AggregateGroups(context.Background(), Aggregation{
+		Count: []string{"*"},
+		Avg:   []string{"price"},
+	}, []string{"color", "year(date_created)"}, None())
+	require.NoError(t, err)
+	assert.Equal(t, []AggregateGroup{
+		{
+			map[string]any{"color": "red", "date_created_year": float64(2023)},
+			AggregateResult{Count: map[string]float64{"*": 2}, Avg: map[string]float64{"price": 1.5}},
+		},
+		{
+			map[string]any{"color": nil, "date_created_year": float64(2024)},
+			AggregateResult{Count: map[string]float64{"*": 1}, Avg: map[string]float64{"price": 3}},
+		},
+	}, groups)
+
+	_, err = api.AggregateGroups(context.Background(), Aggregation{Count: []string{"*"}}, nil, None())
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package directusapi
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// readAliases caches aliases of read models keyed by (*R)(nil)
+var readAliases sync.Map
+
+// tagAlias returns the field aliased by a field of a read model, it's set by the alias tag option,
+// e.g. `json:"recent_comments,alias=comments"` requests comments as recent_comments, so the same
+// relation can be requested twice with different deep queries, e.g. Deep("recent_comments", Limit(3)).
+// Only Directus v9 supports aliases.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#alias
+func tagAlias(f reflect.StructField) (string, bool) {
+	tag, ok := f.Tag.Lookup(tagName)
+	if !ok || !strings.Contains(tag, ",alias=") {
+		return "", false
+	}
+	_, opts, _ := strings.Cut(tag, ",")
+	for _, o := range strings.Split(opts, ",") {
+		if field := strings.TrimPrefix(o, "alias="); field != o && field != "" {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// readModelAliases returns fields aliased by fields of the read model keyed by the alias
+func readModelAliases[R any]() map[string]string {
+	if a, ok := readAliases.Load((*R)(nil)); ok {
+		return a.(map[string]string)
+	}
+	aliases := map[string]string{}
+	if t := reflect.TypeOf((*R)(nil)).Elem(); t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, skip := jsonName(f)
+			if field, ok := tagAlias(f); ok && !skip {
+				aliases[name] = field
+			}
+		}
+	}
+	readAliases.Store((*R)(nil), aliases)
+	return aliases
+}
+
+// fieldParams returns params requesting fields of the read model with extra fields
+func (d API[R, W, PK]) fieldParams(extra ...string) map[string]string {
+	qv := map[string]string{
+		"fields": strings.Join(append(d.jsonFieldsR(), extra...), ","),
+	}
+	d.aliasParams(qv)
+	return qv
+}
+
+// aliasParams adds aliases of the read model to qv, they are left out for v8
+func (d API[R, W, PK]) aliasParams(qv map[string]string) {
+	if d.Version == V8 {
+		return
+	}
+	for alias, field := range readModelAliases[R]() {
+		qv["alias["+alias+"]"] = field
+	}
+}
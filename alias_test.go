@@ -0,0 +1,57 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postCommentR struct {
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+}
+
+type postR struct {
+	ID       int            `json:"id"`
+	Comments []postCommentR `json:"comments"`
+	Recent   []postCommentR `json:"recent_comments,alias=comments"`
+}
+
+func TestAlias(t *testing.T) {
+	var params []map[string]string
+	srv := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := map[string]string{}
+		for k, v := range r.URL.Query() {
+			qv[k] = v[0]
+		}
+		params = append(params, qv)
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"comments":[{"id":1},{"id":2}],"recent_comments":[{"id":2,"text":"new"}]}]}`))
+	}))
+	api := API[postR, postR, int]{
+		Scheme:         "http",
+		Host:           srv.Host,
+		CollectionName: "posts",
+		HTTPClient:     srv.HTTPClient,
+		Version:        V9,
+	}
+
+	posts, err := api.Items(context.Background(), Deep("recent_comments", SortDesc("id").Limit(1)))
+	require.NoError(t, err)
+	assert.Equal(t, []postR{{1, []postCommentR{{ID: 1}, {ID: 2}}, []postCommentR{{2, "new"}}}}, posts)
+	assert.Equal(t, "comments", params[0]["alias[recent_comments]"])
+	assert.Equal(t, "id,comments.id,comments.text,recent_comments.id,recent_comments.text", params[0]["fields"])
+	assert.Equal(t, "1", params[0]["deep[recent_comments][_limit]"])
+
+	api.Version = V8
+	_, err = api.Items(context.Background(), None())
+	require.NoError(t, err)
+	assert.NotContains(t, params[1], "alias[recent_comments]", "v8 doesn't support aliases")
+
+	type nestedR struct {
+		Post postR `json:"post"`
+	}
+	assert.Panics(t, func() { reflectFields[nestedR]() })
+}
@@ -0,0 +1,29 @@
+package directusapi
+
+import "github.com/antoniobuconjic/directusapi/anonymize"
+
+// FieldTransformer replaces a value of a field during export, e.g. to anonymize personal data.
+//
+// Deprecated: use anonymize.Transformer.
+type FieldTransformer = anonymize.Transformer
+
+// NullValue nulls the field.
+//
+// Deprecated: use anonymize.Null.
+func NullValue() FieldTransformer {
+	return anonymize.Null()
+}
+
+// ConstantValue replaces the field with v.
+//
+// Deprecated: use anonymize.Constant.
+func ConstantValue(v any) FieldTransformer {
+	return anonymize.Constant(v)
+}
+
+// ScrambleEmail replaces emails with addresses at example.com derived from a hash of the original.
+//
+// Deprecated: use anonymize.ScrambleEmail.
+func ScrambleEmail() FieldTransformer {
+	return anonymize.ScrambleEmail()
+}
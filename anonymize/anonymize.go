@@ -0,0 +1,85 @@
+// Package anonymize replaces values of fields of exported items, e.g. to anonymize personal data
+// of production content copied into staging by DumpProject of directusapi.
+package anonymize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Transformer replaces a value of a field, values are JSON decoded, returned nil nulls the field
+type Transformer func(value any) any
+
+// Null nulls the field
+func Null() Transformer {
+	return func(any) any {
+		return nil
+	}
+}
+
+// Constant replaces the field with v
+func Constant(v any) Transformer {
+	return func(any) any {
+		return v
+	}
+}
+
+// ScrambleEmail replaces emails with addresses at example.com derived from a hash of the original,
+// the same email is always scrambled to the same address, so unique constraints still hold
+func ScrambleEmail() Transformer {
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			return v
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(s)))
+		return "user-" + hex.EncodeToString(sum[:8]) + "@example.com"
+	}
+}
+
+// ForCollection returns transformers of fields of the collection, keys of all are collection.field or *.field
+func ForCollection(all map[string]Transformer, collection string) map[string]Transformer {
+	out := map[string]Transformer{}
+	for k, t := range all {
+		c, field, ok := strings.Cut(k, ".")
+		if ok && (c == "*" || c == collection) {
+			out[field] = t
+		}
+	}
+	return out
+}
+
+// Items applies transformers of fields to raw items
+func Items(items []json.RawMessage, fields map[string]Transformer) ([]json.RawMessage, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+	out := make([]json.RawMessage, len(items))
+	for i, item := range items {
+		var m map[string]json.RawMessage
+		err := json.Unmarshal(item, &m)
+		if err != nil {
+			return nil, fmt.Errorf("decode item %d: %w", i, err)
+		}
+		for f, t := range fields {
+			raw, ok := m[f]
+			if !ok {
+				continue
+			}
+			var v any
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return nil, fmt.Errorf("decode field %s of item %d: %w", f, i, err)
+			}
+			if m[f], err = json.Marshal(t(v)); err != nil {
+				return nil, fmt.Errorf("marshal field %s of item %d: %w", f, i, err)
+			}
+		}
+		if out[i], err = json.Marshal(m); err != nil {
+			return nil, fmt.Errorf("marshal item %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,38 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems(t *testing.T) {
+	transform := map[string]Transformer{
+		"customers.email": ScrambleEmail(),
+		"*.phone":         Null(),
+		"orders.note":     Constant("redacted"),
+	}
+	items := []json.RawMessage{
+		json.RawMessage(`{"id":1,"email":"Jane@Example.org","phone":"+420 123","note":"vip"}`),
+		json.RawMessage(`{"id":2,"email":"jane@example.org"}`),
+	}
+
+	out, err := Items(items, ForCollection(transform, "customers"))
+	require.NoError(t, err)
+	var customers []map[string]any
+	for _, o := range out {
+		var c map[string]any
+		require.NoError(t, json.Unmarshal(o, &c))
+		customers = append(customers, c)
+	}
+	assert.Regexp(t, `^user-[0-9a-f]{16}@example\.com$`, customers[0]["email"])
+	assert.Equal(t, customers[0]["email"], customers[1]["email"])
+	assert.Nil(t, customers[0]["phone"])
+	assert.Contains(t, customers[0], "phone")
+	assert.Equal(t, "vip", customers[0]["note"])
+	assert.NotContains(t, customers[1], "phone")
+
+	assert.Len(t, ForCollection(transform, "orders"), 2)
+}
@@ -0,0 +1,188 @@
+package directusapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secrets in failure artifacts
+const redacted = "REDACTED"
+
+// secretHeaders and secretFields are redacted from failure artifacts
+var (
+	secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+	secretFields  = []string{"password", "token", "access_token", "refresh_token", "otp", "secret", "tfa_secret"}
+	// secretValues matches string values of secret fields in bodies which aren't a valid JSON, e.g. truncated ones
+	secretValues = regexp.MustCompile(`("(?i:` + strings.Join(secretFields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+)
+
+// ArtifactStore persists request and response pairs of failed requests, so failures reported
+// from production can be reproduced. Artifacts are written for unexpected statuses and responses
+// which can't be decoded, credentials are redacted.
+type ArtifactStore struct {
+	// Dir is a directory the artifacts are written to as <id>.json
+	Dir string
+}
+
+// ArtifactError wraps an error of a request whose artifact was persisted
+type ArtifactError struct {
+	// ID is the correlation id of the artifact
+	ID string
+	// Path is where the artifact was written
+	Path string
+	Err  error
+}
+
+func (e *ArtifactError) Error() string {
+	return fmt.Sprintf("%v (failure artifact %s)", e.Err, e.ID)
+}
+
+func (e *ArtifactError) Unwrap() error {
+	return e.Err
+}
+
+// Artifact is a persisted failed request, it's written as JSON
+type Artifact struct {
+	ID       string           `json:"id"`
+	Error    string           `json:"error"`
+	Request  ArtifactRequest  `json:"request"`
+	Response ArtifactResponse `json:"response"`
+}
+
+// ArtifactRequest is the request of an Artifact
+type ArtifactRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Header http.Header     `json:"header"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// ArtifactResponse is the response of an Artifact, Body is kept as text as it may not be a valid JSON
+type ArtifactResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// saveArtifact persists the failed request to Artifacts, the header of FlowSignature is redacted as well
+func (a *API[R, W, PK]) saveArtifact(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, err error) error {
+	var headers []string
+	if a.FlowSignature != nil {
+		headers = append(headers, a.FlowSignature.header())
+	}
+	return a.Artifacts.save(req, reqBody, resp, respBody, err, headers...)
+}
+
+// save persists the failed request and wraps err with the correlation id, headers are redacted
+// together with secretHeaders. Failures to write the artifact leave err as it is.
+func (s *ArtifactStore) save(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, err error, headers ...string) error {
+	if s == nil {
+		return err
+	}
+	var idBytes [8]byte
+	if _, rerr := rand.Read(idBytes[:]); rerr != nil {
+		return err
+	}
+	a := Artifact{
+		ID:    hex.EncodeToString(idBytes[:]),
+		Error: redactText(err.Error()),
+		Request: ArtifactRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL),
+			Header: redactHeader(req.Header, headers),
+			Body:   redactBody(reqBody),
+		},
+		Response: ArtifactResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header, headers),
+			Body:       redactResponseBody(respBody),
+		},
+	}
+	data, merr := json.MarshalIndent(a, "", "  ")
+	if merr != nil {
+		return err
+	}
+	path := filepath.Join(s.Dir, a.ID+".json")
+	if werr := os.WriteFile(path, data, 0o600); werr != nil {
+		return err
+	}
+	return &ArtifactError{a.ID, path, err}
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	qv := c.Query()
+	for k := range qv {
+		if containsString(secretFields, strings.ToLower(k)) {
+			qv.Set(k, redacted)
+		}
+	}
+	c.RawQuery = qv.Encode()
+	return c.String()
+}
+
+func redactHeader(h http.Header, headers []string) http.Header {
+	c := h.Clone()
+	for _, k := range append(headers, secretHeaders...) {
+		if c.Get(k) != "" {
+			c.Set(k, redacted)
+		}
+	}
+	return c
+}
+
+// redactBody replaces values of secret fields of JSON bodies, other bodies are dropped
+func redactBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// redactResponseBody replaces values of secret fields of response bodies, e.g. tokens of /auth/login,
+// bodies which aren't a valid JSON are kept with string values of secret fields replaced
+func redactResponseBody(body []byte) string {
+	if out := redactBody(body); out != nil {
+		return string(out)
+	}
+	return redactText(string(body))
+}
+
+// redactText replaces string values of secret fields in text, e.g. in errors quoting the response
+func redactText(s string) string {
+	return secretValues.ReplaceAllString(s, `${1}"`+redacted+`"`)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if containsString(secretFields, strings.ToLower(k)) {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(val)
+			}
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return v
+}
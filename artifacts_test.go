@@ -0,0 +1,89 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifacts(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"invalid"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"one"}}`))
+	}))
+	api.BearerToken = "s3cr3t"
+	api.Artifacts = &ArtifactStore{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	_, err := api.Create(ctx, map[string]any{"name": "kiwi", "password": "hunter2"})
+	var artErr *ArtifactError
+	require.True(t, errors.As(err, &artErr), err)
+	var respErr *ResponseError
+	assert.True(t, errors.As(err, &respErr))
+	assert.Contains(t, err.Error(), artErr.ID)
+
+	data, err := os.ReadFile(artErr.Path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.NotContains(t, string(data), "hunter2")
+	var a Artifact
+	require.NoError(t, json.Unmarshal(data, &a))
+	assert.Equal(t, artErr.ID, a.ID)
+	assert.Equal(t, http.MethodPost, a.Request.Method)
+	assert.JSONEq(t, `{"name":"kiwi","password":"REDACTED"}`, string(a.Request.Body))
+	assert.Equal(t, http.StatusBadRequest, a.Response.StatusCode)
+
+	_, err = api.GetByID(ctx, 1)
+	require.True(t, errors.As(err, &artErr), err)
+	data, err = os.ReadFile(artErr.Path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &a))
+	assert.Equal(t, `{"data":{"id":"one"}}`, a.Response.Body)
+}
+
+func TestArtifactsRedactResponses(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// a valid JSON which doesn't decode into the read model
+			_, _ = w.Write([]byte(`{"data":{"id":"one","access_token":"tok-1"}}`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"data":{"refresh_token":"tok-2","tfa_secret":"tok-3`))
+		}
+	}))
+	api.Artifacts = &ArtifactStore{Dir: t.TempDir()}
+	api.FlowSignature = &FlowSignature{Secret: "flow-s3cr3t", Plain: true}
+	ctx := context.Background()
+
+	read := func(err error) Artifact {
+		var artErr *ArtifactError
+		require.True(t, errors.As(err, &artErr), err)
+		data, err := os.ReadFile(artErr.Path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "tok-")
+		assert.NotContains(t, string(data), "flow-s3cr3t")
+		var a Artifact
+		require.NoError(t, json.Unmarshal(data, &a))
+		return a
+	}
+
+	_, err := api.GetByID(ctx, 1)
+	a := read(err)
+	assert.JSONEq(t, `{"data":{"id":"one","access_token":"REDACTED"}}`, a.Response.Body)
+
+	_, err = api.TriggerFlow(ctx, "flow", map[string]any{"name": "kiwi"})
+	a = read(err)
+	assert.Equal(t, `{"data":{"refresh_token":"REDACTED","tfa_secret":"REDACTED"`, a.Response.Body)
+	assert.Equal(t, "REDACTED", a.Request.Header.Get("X-Signature"))
+}
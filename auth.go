@@ -0,0 +1,482 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antoniobuconjic/directusapi/tokenstore"
+)
+
+// TokenProvider provides the bearer token of requests, e.g. from a secret manager rotating it.
+// The token can vary per request by values of ctx.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenProvider of a token which never changes
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+type tokenCtxKey struct{}
+
+// WithToken returns a context executing requests with token instead of the token of the API,
+// e.g. on behalf of an end user with their own Directus token
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenCtxKey{}, token)
+}
+
+// AuthTokens are tokens of an authenticated session, they are persisted by package tokenstore
+type AuthTokens = tokenstore.Tokens
+
+// Login authenticates with provided credentials and returns tokens of a new session,
+// unlike CreateToken it returns also the refresh token and the expiration of the access token.
+// otp is a one-time password of users with two-factor authentication enabled, it's ignored when empty.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#login
+// https://v8.docs.directus.io/api/authentication.html#retrieve-a-temporary-access-token
+func (d API[R, W, PK]) Login(ctx context.Context, email, password, otp string) (AuthTokens, error) {
+	return d.LoginWithMode(ctx, AuthModeJSON, email, password, otp)
+}
+
+// AuthMode is how v9 returns tokens of a new session
+type AuthMode string
+
+const (
+	// AuthModeJSON returns both tokens in the response
+	AuthModeJSON AuthMode = "json"
+	// AuthModeCookie returns the access token in the response and sets the refresh token as a cookie
+	AuthModeCookie AuthMode = "cookie"
+	// AuthModeSession sets a session cookie which authenticates following requests, it requires Directus 10.
+	// BearerToken has to be empty, because a token takes precedence over the cookie.
+	AuthModeSession AuthMode = "session"
+)
+
+// LoginWithMode authenticates like Login with the given mode, v8 supports only AuthModeJSON.
+// Cookie modes require HTTPClient with a cookie jar, which stores the cookies and sends them
+// with following requests. Tokens set as cookies are not returned.
+func (d API[R, W, PK]) LoginWithMode(ctx context.Context, mode AuthMode, email, password, otp string) (AuthTokens, error) {
+	if mode != AuthModeJSON {
+		if d.Version == V8 {
+			return AuthTokens{}, fmt.Errorf("%s mode is supported only by v9", mode)
+		}
+		if d.HTTPClient == nil || d.HTTPClient.Jar == nil {
+			return AuthTokens{}, fmt.Errorf("%s mode requires HTTPClient with a cookie jar", mode)
+		}
+	}
+	if d.Version == V8 {
+		token, err := d.authenticate(ctx, email, password, otp)
+		if err != nil {
+			return AuthTokens{}, err
+		}
+		return AuthTokens{
+			AccessToken:  token,
+			RefreshToken: token,
+			Expires:      jwtExpiration(token),
+		}, nil
+	}
+
+	body := map[string]string{"email": email, "password": password, "mode": string(mode)}
+	if otp != "" {
+		body["otp"] = otp
+	}
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/auth/login",
+		nil,
+		body,
+	}
+	var respBody struct {
+		Data authTokensV9 `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("execute login request: %w", err)
+	}
+	return respBody.Data.tokens(d.clock().Now()), nil
+}
+
+// Refresh renews tokens of a session, the previous refresh token is invalidated by v9
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#refresh
+// https://v8.docs.directus.io/api/authentication.html#refresh-a-temporary-access-token
+func (d API[R, W, PK]) Refresh(ctx context.Context, refreshToken string) (AuthTokens, error) {
+	if d.Version == V8 {
+		req := request{
+			ctx,
+			http.MethodPost,
+			d.baseURL() + "/auth/refresh",
+			nil,
+			map[string]string{"token": refreshToken},
+		}
+		var respBody struct {
+			Data struct {
+				Token string `json:"token"`
+			} `json:"data"`
+		}
+		err := d.executeRequest(req, http.StatusOK, &respBody)
+		if err != nil {
+			return AuthTokens{}, fmt.Errorf("execute refresh request: %w", err)
+		}
+		return AuthTokens{
+			AccessToken:  respBody.Data.Token,
+			RefreshToken: respBody.Data.Token,
+			Expires:      jwtExpiration(respBody.Data.Token),
+		}, nil
+	}
+
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/auth/refresh",
+		nil,
+		map[string]string{"refresh_token": refreshToken, "mode": "json"},
+	}
+	var respBody struct {
+		Data authTokensV9 `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("execute refresh request: %w", err)
+	}
+	return respBody.Data.tokens(d.clock().Now()), nil
+}
+
+// Logout invalidates the refresh token of a session, it's supported only by v9
+// because v8 tokens are valid until they expire
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#logout
+func (d API[R, W, PK]) Logout(ctx context.Context, refreshToken string) error {
+	if d.Version == V8 {
+		return errors.New("logout is supported only by v9")
+	}
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/auth/logout",
+		nil,
+		map[string]string{"refresh_token": refreshToken},
+	}
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute logout request: %w", err)
+	}
+	return nil
+}
+
+// PasswordRequest sends an email with a password reset link to the user,
+// resetURL is the page of the application the link points to with the token in its query
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#request-password-reset
+// https://v8.docs.directus.io/api/authentication.html#request-a-password-reset
+func (d API[R, W, PK]) PasswordRequest(ctx context.Context, email, resetURL string) error {
+	body := map[string]string{"email": email}
+	if resetURL != "" {
+		body["reset_url"] = resetURL
+	}
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/auth/password/request",
+		nil,
+		body,
+	}
+	err := d.executeRequest(req, d.emptyResponseStatus(), nil)
+	if err != nil {
+		return fmt.Errorf("execute password request request: %w", err)
+	}
+	return nil
+}
+
+// PasswordReset sets a new password of the user identified by the token from the reset link
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#reset-a-password
+// https://v8.docs.directus.io/api/authentication.html#reset-a-password
+func (d API[R, W, PK]) PasswordReset(ctx context.Context, token, newPassword string) error {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/auth/password/reset",
+		nil,
+		map[string]string{"token": token, "password": newPassword},
+	}
+	err := d.executeRequest(req, d.emptyResponseStatus(), nil)
+	if err != nil {
+		return fmt.Errorf("execute password reset request: %w", err)
+	}
+	return nil
+}
+
+// emptyResponseStatus is the status of auth endpoints without a response body
+func (d API[R, W, PK]) emptyResponseStatus() int {
+	if d.Version == V8 {
+		return http.StatusOK
+	}
+	return http.StatusNoContent
+}
+
+type authTokensV9 struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// Expires is a lifetime of the access token in milliseconds
+	Expires int64 `json:"expires"`
+}
+
+func (t authTokensV9) tokens(now time.Time) AuthTokens {
+	expires := jwtExpiration(t.AccessToken)
+	if t.Expires > 0 {
+		expires = now.Add(time.Duration(t.Expires) * time.Millisecond)
+	}
+	return AuthTokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		Expires:      expires,
+	}
+}
+
+// jwtExpiration reads the exp claim of a JWT without verifying it, it returns zero time when there is none
+func jwtExpiration(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+const (
+	defaultRefreshMargin = time.Minute
+	defaultRefreshJitter = 10 * time.Second
+	defaultRefreshRetry  = 5 * time.Second
+)
+
+// TokenRefresher renews the access token in a background goroutine before it expires,
+// so requests never wait for a refresh. Set it to API.TokenRefresher of all instances
+// which should use its token, it takes precedence over BearerToken.
+type TokenRefresher struct {
+	// Margin is how long before the expiration the token is renewed, defaults to a minute
+	Margin time.Duration
+	// Store is optional, when set renewed tokens are saved to it, see NewStoredAuthManager
+	Store TokenStore
+	// Jitter is a maximum random duration subtracted from the refresh time,
+	// so many processes started together don't refresh at once.
+	// Defaults to 10 seconds, negative value disables it.
+	Jitter time.Duration
+	// RetryInterval is a delay before a failed refresh is retried, defaults to 5 seconds
+	RetryInterval time.Duration
+	// OnError is called when a refresh fails
+	OnError func(error)
+
+	refresh   func(ctx context.Context, refreshToken string) (AuthTokens, error)
+	lifecycle *Lifecycle
+	clock     Clock
+	rand      Rand
+
+	mu     sync.RWMutex
+	tokens AuthTokens
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewTokenRefresher creates a refresher of tokens which uses this API instance to renew them
+func (d API[R, W, PK]) NewTokenRefresher(tokens AuthTokens) *TokenRefresher {
+	if tokens.Expires.IsZero() {
+		tokens.Expires = jwtExpiration(tokens.AccessToken)
+	}
+	return &TokenRefresher{
+		refresh:   d.Refresh,
+		lifecycle: d.Lifecycle,
+		clock:     d.clock(),
+		rand:      d.rand(),
+		tokens:    tokens,
+	}
+}
+
+// Tokens returns the current tokens
+func (r *TokenRefresher) Tokens() AuthTokens {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.tokens
+}
+
+// Token returns the current access token
+func (r *TokenRefresher) Token(context.Context) (string, error) {
+	return r.Tokens().AccessToken, nil
+}
+
+// Start starts the background refresh, it's stopped by Stop, by ctx or when the client is closed
+func (r *TokenRefresher) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return fmt.Errorf("token refresher is already started")
+	}
+	ctx, done, err := r.lifecycle.background(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func(finished chan struct{}) {
+		defer close(finished)
+		defer done()
+		r.run(ctx)
+	}(r.done)
+	return nil
+}
+
+// Stop stops the background refresh and waits until the goroutine exits
+func (r *TokenRefresher) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (r *TokenRefresher) run(ctx context.Context) {
+	timer := r.clock.NewTimer(r.nextRefresh())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		tokens, err := r.refresh(ctx, r.Tokens().RefreshToken)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if r.OnError != nil {
+				r.OnError(err)
+			}
+			timer.Reset(orDefault(r.RetryInterval, defaultRefreshRetry))
+			continue
+		}
+		r.mu.Lock()
+		r.tokens = tokens
+		r.mu.Unlock()
+		if r.Store != nil {
+			// the old refresh token may be invalidated already, the new one has to survive a restart
+			if err := r.Store.Save(ctx, tokens); err != nil && r.OnError != nil {
+				r.OnError(fmt.Errorf("save refreshed tokens: %w", err))
+			}
+		}
+		timer.Reset(r.nextRefresh())
+	}
+}
+
+// nextRefresh returns a delay until the next refresh
+func (r *TokenRefresher) nextRefresh() time.Duration {
+	expires := r.Tokens().Expires
+	if expires.IsZero() {
+		// unknown expiration can't be planned, the token is used until it's replaced
+		return time.Duration(1<<63 - 1)
+	}
+	at := expires.Add(-orDefault(r.Margin, defaultRefreshMargin))
+	if jitter := orDefault(r.Jitter, defaultRefreshJitter); jitter > 0 {
+		at = at.Add(-time.Duration(r.rand.Int63n(int64(jitter))))
+	}
+	if d := at.Sub(r.clock.Now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// AuthManager renews the access token on demand, right before a request would be sent
+// with a token expiring within Margin. Concurrent requests wait for a single refresh
+// instead of each refreshing the token. Set it to API.Auth of all instances which should use its token,
+// it takes precedence over TokenRefresher and BearerToken.
+type AuthManager struct {
+	// Margin is how long before the expiration the token is renewed, defaults to a minute
+	Margin time.Duration
+	// Store is optional, when set renewed tokens are saved to it, see NewStoredAuthManager
+	Store TokenStore
+
+	refresh func(ctx context.Context, refreshToken string) (AuthTokens, error)
+	clock   Clock
+
+	mu     sync.Mutex
+	tokens AuthTokens
+}
+
+// NewAuthManager creates a manager of tokens which uses this API instance to renew them
+func (d API[R, W, PK]) NewAuthManager(tokens AuthTokens) *AuthManager {
+	if tokens.Expires.IsZero() {
+		tokens.Expires = jwtExpiration(tokens.AccessToken)
+	}
+	// the refresh request must not wait for the manager itself
+	d.Auth, d.TokenRefresher, d.TokenProvider = nil, nil, nil
+	return &AuthManager{
+		refresh: d.Refresh,
+		clock:   d.clock(),
+		tokens:  tokens,
+	}
+}
+
+// Tokens returns the current tokens
+func (m *AuthManager) Tokens() AuthTokens {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tokens
+}
+
+// Token returns the access token, it's refreshed first when it's close to its expiration
+func (m *AuthManager) Token(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := m.tokens.Expires
+	if expires.IsZero() || m.clock.Now().Before(expires.Add(-orDefault(m.Margin, defaultRefreshMargin))) {
+		return m.tokens.AccessToken, nil
+	}
+	tokens, err := m.refresh(ctx, m.tokens.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("refresh access token: %w", err)
+	}
+	m.tokens = tokens
+	if m.Store != nil {
+		// the old refresh token may be invalidated already, the new one has to survive a restart
+		if err := m.Store.Save(ctx, tokens); err != nil {
+			return "", fmt.Errorf("save refreshed tokens: %w", err)
+		}
+	}
+	return tokens.AccessToken, nil
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
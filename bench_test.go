@@ -0,0 +1,133 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Allocation budgets of the hot path with some headroom, tests fail when a change exceeds them.
+// Update them deliberately together with the benchmark results in the commit message.
+const (
+	// fields of FruitR computed by reflection, measured 31
+	allocBudgetFields = 40
+	// serialization of benchQuery, measured 15 for v8 and 30 for v9
+	allocBudgetQueryV8 = 20
+	allocBudgetQueryV9 = 40
+	// Items call decoding benchItemsCount items of FruitR, measured 259
+	allocBudgetItems = 320
+)
+
+const benchItemsCount = 20
+
+func benchQuery() Query {
+	return Eq("status", "published").
+		Neq("category", "red").
+		In("area", "europe,africa").
+		Between("weight", "1", "10").
+		Nnull("discovered_at").
+		SortDesc("id").
+		Limit(20).
+		Offset(40)
+}
+
+// staticTransport responds every request with the same body
+type staticTransport []byte
+
+func (t staticTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(t)),
+		Request:    r,
+	}, nil
+}
+
+func benchItemsAPI() API[FruitR, FruitW, int] {
+	items := make([]string, benchItemsCount)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{
+			"id": %d, "name": "watermelon", "weight": 20, "status": "published", "category": "green",
+			"enabled": true, "price": 120.36, "discovered_at": "2022-05-05 10:30:00", "area": ["europe", "africa"],
+			"favorites": {"josef": "10"}, "lefield": {"id": 1, "email": "email@example.com"}, "poc": null
+		}`, i)
+	}
+	body := `{"data": [` + strings.Join(items, ",") + `]}`
+	return API[FruitR, FruitW, int]{
+		Scheme:         "http",
+		Host:           "localhost:8080",
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient:     &http.Client{Transport: staticTransport(body)},
+	}
+}
+
+func BenchmarkFields(b *testing.B) {
+	t := reflect.TypeOf(FruitR{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		iterateFields(t, "")
+	}
+}
+
+func BenchmarkQueryV8(b *testing.B) {
+	q := benchQuery()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.asKeyValue(V8)
+	}
+}
+
+func BenchmarkQueryV9(b *testing.B) {
+	q := benchQuery()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.asKeyValue(V9)
+	}
+}
+
+func BenchmarkItems(b *testing.B) {
+	api := benchItemsAPI()
+	ctx := context.Background()
+	q := benchQuery()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := api.Items(ctx, q); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAllocBudgets(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("allocation budgets are not checked in short mode and with the race detector")
+	}
+	api := benchItemsAPI()
+	ctx := context.Background()
+	q := benchQuery()
+	fruitT := reflect.TypeOf(FruitR{})
+
+	budgets := []struct {
+		name   string
+		budget float64
+		fn     func()
+	}{
+		{"fields", allocBudgetFields, func() { iterateFields(fruitT, "") }},
+		{"query v8", allocBudgetQueryV8, func() { q.asKeyValue(V8) }},
+		{"query v9", allocBudgetQueryV9, func() { q.asKeyValue(V9) }},
+		{"items", allocBudgetItems, func() { _, _ = api.Items(ctx, q) }},
+	}
+	for _, b := range budgets {
+		allocs := testing.AllocsPerRun(100, b.fn)
+		t.Logf("%s: %.0f allocs (budget %.0f)", b.name, allocs, b.budget)
+		if allocs > b.budget {
+			t.Errorf("%s exceeded its allocation budget: %.0f > %.0f", b.name, allocs, b.budget)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultChunkSize = 100
+
+// BulkOptions controls how bulk operations split items into requests
+type BulkOptions struct {
+	// ChunkSize is a maximum number of items per request, defaults to 100
+	ChunkSize int
+	// MaxChunkBytes is a maximum size of a request body,
+	// defaults to API.MaxPayloadSize, the limit advertised by the server or DefaultMaxPayloadSize
+	MaxChunkBytes int64
+	// Concurrency is a number of requests executed in parallel, defaults to 1
+	Concurrency int
+}
+
+// ChunkError is a failure of a single request of a bulk operation,
+// Start and End are indices of its items in the input slice
+type ChunkError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+// BulkError reports failed chunks of a bulk operation, items of other chunks succeeded
+type BulkError struct {
+	Chunks []ChunkError
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Chunks))
+	for i, c := range e.Chunks {
+		msgs[i] = fmt.Sprintf("items [%d:%d]: %v", c.Start, c.End, c.Err)
+	}
+	return fmt.Sprintf("%d chunks failed: %s", len(e.Chunks), strings.Join(msgs, "; "))
+}
+
+// BulkResult is an outcome of a bulk operation for every input item,
+// failed items can be retried by their Index in the input slice
+type BulkResult[R any] struct {
+	Items  []BulkItemResult[R]
+	chunks []ChunkError
+}
+
+// BulkItemResult is an outcome of a single item of a bulk operation,
+// Item is zero valued when the item failed
+type BulkItemResult[R any] struct {
+	Index int
+	Item  R
+	Err   error
+}
+
+// Errors returns errors reported by Directus for the item
+func (r BulkItemResult[R]) Errors() []ErrorDetail {
+	var respErr *ResponseError
+	if errors.As(r.Err, &respErr) {
+		return respErr.Errors
+	}
+	return nil
+}
+
+// Succeeded returns items which succeeded in order of the input
+func (r BulkResult[R]) Succeeded() []R {
+	out := []R{}
+	for _, i := range r.Items {
+		if i.Err == nil {
+			out = append(out, i.Item)
+		}
+	}
+	return out
+}
+
+// Failed returns results of items which failed
+func (r BulkResult[R]) Failed() []BulkItemResult[R] {
+	out := []BulkItemResult[R]{}
+	for _, i := range r.Items {
+		if i.Err != nil {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Err returns *BulkError when any item failed
+func (r BulkResult[R]) Err() error {
+	if len(r.chunks) == 0 {
+		return nil
+	}
+	return &BulkError{r.chunks}
+}
+
+// chunk is a range of encoded items sent in one request
+type chunk struct {
+	start, end int
+	body       json.RawMessage
+}
+
+// InsertMany inserts items in chunks split by count and serialized size.
+// Result contains every item in order of the input, when some chunks fail
+// the returned error is *BulkError and all items of failed chunks are marked failed.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/items.html#create-multiple-items
+// https://v8.docs.directus.io/api/items.html#create-an-item
+func (d API[R, W, PK]) InsertMany(ctx context.Context, items []W, opts BulkOptions) (BulkResult[R], error) {
+	encoded := make([][]byte, len(items))
+	for i, item := range items {
+		if err := d.Validator.item(ctx, &item); err != nil {
+			return BulkResult[R]{}, fmt.Errorf("item %d: %w", i, err)
+		}
+		body, err := marshalBody(item)
+		if err != nil {
+			return BulkResult[R]{}, fmt.Errorf("marshal item %d: %w", i, err)
+		}
+		if body, err = d.scopeBody(ctx, body); err != nil {
+			return BulkResult[R]{}, err
+		}
+		if body, err = d.ownBody(ctx, body); err != nil {
+			return BulkResult[R]{}, err
+		}
+		if raw, ok := body.(json.RawMessage); ok {
+			encoded[i] = raw
+		} else if encoded[i], err = json.Marshal(body); err != nil {
+			return BulkResult[R]{}, fmt.Errorf("marshal item %d: %w", i, err)
+		}
+	}
+
+	d.loadServerInfo(ctx)
+	maxBytes := opts.MaxChunkBytes
+	if maxBytes <= 0 {
+		maxBytes = d.maxPayloadSize()
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxPayloadSize
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	chunks := splitChunks(encoded, chunkSize, maxBytes)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	res := BulkResult[R]{Items: make([]BulkItemResult[R], len(items))}
+	for i := range res.Items {
+		res.Items[i].Index = i
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, c := range chunks {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(c chunk) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			created, err := d.insertChunk(ctx, c.body)
+			if err == nil && len(created) != c.end-c.start {
+				err = fmt.Errorf("server returned %d items instead of %d", len(created), c.end-c.start)
+			}
+			if err != nil {
+				mu.Lock()
+				res.chunks = append(res.chunks, ChunkError{c.start, c.end, err})
+				mu.Unlock()
+				for i := c.start; i < c.end; i++ {
+					res.Items[i].Err = err
+				}
+				return
+			}
+			for i, item := range created {
+				res.Items[c.start+i].Item = item
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	sort.Slice(res.chunks, func(i, j int) bool {
+		return res.chunks[i].Start < res.chunks[j].Start
+	})
+	return res, res.Err()
+}
+
+func (d API[R, W, PK]) insertChunk(ctx context.Context, body json.RawMessage) ([]R, error) {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.itemsURL(),
+		d.fieldParams(),
+		body,
+	}
+	var respBody itemsEnvelope[R]
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute insert many request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// splitChunks groups encoded items into JSON arrays with at most size items and maxBytes bytes,
+// an item bigger than maxBytes gets its own chunk
+func splitChunks(encoded [][]byte, size int, maxBytes int64) []chunk {
+	var chunks []chunk
+	start := 0
+	// brackets of the array
+	bytesLen := int64(2)
+	for i, e := range encoded {
+		itemLen := int64(len(e))
+		if i > start {
+			// separating comma
+			itemLen++
+		}
+		if i > start && (i-start >= size || bytesLen+itemLen > maxBytes) {
+			chunks = append(chunks, newChunk(encoded, start, i))
+			start, bytesLen, itemLen = i, 2, int64(len(e))
+		}
+		bytesLen += itemLen
+	}
+	if start < len(encoded) {
+		chunks = append(chunks, newChunk(encoded, start, len(encoded)))
+	}
+	return chunks
+}
+
+func newChunk(encoded [][]byte, start, end int) chunk {
+	body := bytes.Join(encoded[start:end], []byte(","))
+	return chunk{start, end, json.RawMessage(append(append([]byte("["), body...), ']'))}
+}
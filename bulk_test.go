@@ -0,0 +1,63 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChunks(t *testing.T) {
+	encoded := [][]byte{[]byte(`"aaaa"`), []byte(`"b"`), []byte(`"cc"`), []byte(`"dddddddddd"`), []byte(`"e"`)}
+
+	chunks := splitChunks(encoded, 2, 12)
+	var bodies []string
+	for _, c := range chunks {
+		bodies = append(bodies, string(c.body))
+		assert.True(t, json.Valid(c.body))
+	}
+	assert.Equal(t, []string{`["aaaa","b"]`, `["cc"]`, `["dddddddddd"]`, `["e"]`}, bodies)
+	assert.Equal(t, []int{0, 2, 3, 4}, []int{chunks[0].start, chunks[1].start, chunks[2].start, chunks[3].start})
+}
+
+func TestInsertMany(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []FruitW
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&items))
+		if items[0].Name == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"invalid name","extensions":{"code":"INVALID_PAYLOAD"}}]}`))
+			return
+		}
+		out := make([]FruitR, len(items))
+		for i, item := range items {
+			out[i] = FruitR{ID: len(item.Name), Name: item.Name}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": out})
+	}))
+
+	in := []FruitW{{Name: "a"}, {Name: "bb"}, {Name: "bad"}, {Name: "dddd"}, {Name: "eeeee"}}
+	res, err := api.InsertMany(context.Background(), in, BulkOptions{ChunkSize: 2, Concurrency: 2})
+
+	var bulkErr *BulkError
+	require.True(t, errors.As(err, &bulkErr), fmt.Sprint(err))
+	require.Len(t, bulkErr.Chunks, 1)
+	assert.Equal(t, 2, bulkErr.Chunks[0].Start)
+	assert.Equal(t, 4, bulkErr.Chunks[0].End)
+
+	require.Len(t, res.Items, 5)
+	succeeded := []string{}
+	for _, f := range res.Succeeded() {
+		succeeded = append(succeeded, f.Name)
+	}
+	assert.Equal(t, []string{"a", "bb", "eeeee"}, succeeded)
+	failed := res.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, []int{2, 3}, []int{failed[0].Index, failed[1].Index})
+	assert.Equal(t, []ErrorDetail{{"INVALID_PAYLOAD", "invalid name"}}, failed[1].Errors())
+}
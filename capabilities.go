@@ -0,0 +1,77 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Capabilities are optional features of the server, applications can use them as feature flags.
+// Features are probed with the token of the API, a feature the token isn't allowed to use is reported as disabled.
+type Capabilities struct {
+	// Version is empty when the token isn't allowed to see it
+	Version string
+	// WebSockets is set when the realtime WebSocket endpoint is enabled
+	WebSockets bool
+	// ResumableUploads is set when tus uploads of files are enabled
+	ResumableUploads bool
+	// ContentVersioning is set when the versions endpoint is available
+	ContentVersioning bool
+	// GraphQL is set when the GraphQL endpoint answers queries
+	GraphQL bool
+}
+
+// Capabilities probes which optional features the server supports.
+// Directus v8 has none of them, only its version is retrieved.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/server.html#get-server-info
+func (d API[R, W, PK]) Capabilities(ctx context.Context) (Capabilities, error) {
+	var c Capabilities
+	info, err := d.serverInfo(ctx)
+	if err != nil {
+		return c, err
+	}
+	c.Version = info.version()
+	d.checkCompatibility(c.Version)
+	if d.Version == V8 {
+		return c, nil
+	}
+
+	c.WebSockets = enabledInfo(info.WebSocket)
+	c.ResumableUploads = enabledInfo(info.Uploads)
+	if c.ContentVersioning, err = d.probe(ctx, d.baseURL()+"/versions", map[string]string{"limit": "0"}); err != nil {
+		return c, err
+	}
+	if c.GraphQL, err = d.probe(ctx, d.baseURL()+"/graphql", map[string]string{"query": "{__typename}"}); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// enabledInfo reports whether a feature section of server info is present and not false
+func enabledInfo(raw []byte) bool {
+	switch string(raw) {
+	case "", "null", "false":
+		return false
+	}
+	return true
+}
+
+// probe reports whether a GET request of the endpoint succeeds, error responses mean it isn't available
+func (d API[R, W, PK]) probe(ctx context.Context, u string, qv map[string]string) (bool, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	var respBody struct{}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,36 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_/server/info":
+			_, _ = w.Write([]byte(`{"data":{"directus":{"version":"9.26.0"},"websocket":{"rest":{"path":"/websocket"}},"uploads":false}}`))
+		case "/_/versions":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Route doesn't exist","extensions":{"code":"ROUTE_NOT_FOUND"}}]}`))
+		case "/_/graphql":
+			assert.Equal(t, "{__typename}", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"data":{"__typename":"Query"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	api.Version = V9
+
+	c, err := api.Capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{
+		Version:    "9.26.0",
+		WebSockets: true,
+		GraphQL:    true,
+	}, c)
+}
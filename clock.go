@@ -0,0 +1,160 @@
+package directusapi
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Clock is a source of time used by background and retry logic,
+// it can be replaced so tests of dependent code don't need to sleep
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a Clock's counterpart of time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Rand is a source of randomness used for jitter, *rand.Rand implements it
+type Rand interface {
+	Int63n(n int64) int64
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type systemRand struct{}
+
+func (systemRand) Int63n(n int64) int64 {
+	return rand.Int63n(n)
+}
+
+func (d API[R, W, PK]) clock() Clock {
+	if d.Clock == nil {
+		return systemClock{}
+	}
+	return d.Clock
+}
+
+func (d API[R, W, PK]) rand() Rand {
+	if d.Rand == nil {
+		return systemRand{}
+	}
+	return d.Rand
+}
+
+// FakeClock is a Clock which only moves when Advance is called, it's meant for tests
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock creates a fake clock set to now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.resetLocked(d)
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward and fires all timers which are due
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if t.active && !t.at.After(c.now) {
+			t.active = false
+			select {
+			case t.c <- c.now:
+			default:
+			}
+		}
+	}
+}
+
+// Timers returns the number of active timers, so tests can wait until
+// the code under test is blocked on the clock before advancing it
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, t := range c.timers {
+		if t.active {
+			n++
+		}
+	}
+	return n
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	c      chan time.Time
+	at     time.Time
+	active bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.resetLocked(d)
+	return wasActive
+}
+
+func (t *fakeTimer) resetLocked(d time.Duration) {
+	t.at = t.clock.now.Add(d)
+	t.active = true
+	if d <= 0 {
+		t.active = false
+		select {
+		case t.c <- t.clock.now:
+		default:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/antoniobuconjic/directusapi"
+)
+
+func items(ctx context.Context, api itemsAPI, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	cmd, args := args[0], args[1:]
+	api.CollectionName = args[0]
+
+	switch cmd {
+	case "get":
+		if len(args) != 2 {
+			return errUsage
+		}
+		it, err := api.GetByID(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, it)
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ContinueOnError)
+		limit := fs.Int("limit", -1, "maximum number of items, -1 for all items")
+		offset := fs.Int("offset", 0, "number of items to skip")
+		sort := fs.String("sort", "", "comma separated fields to sort by, prefix a field with - for descending order")
+		filters := filterFlag{}
+		fs.Var(filters, "filter", "field=value equality filter, can be repeated")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		q := directusapi.Limit(*limit).Offset(*offset)
+		for k, v := range filters {
+			q = q.Eq(k, v)
+		}
+		if *sort != "" {
+			for _, s := range strings.Split(*sort, ",") {
+				if strings.HasPrefix(s, "-") {
+					q = q.SortDesc(strings.TrimPrefix(s, "-"))
+				} else {
+					q = q.SortAsc(s)
+				}
+			}
+		}
+		its, err := api.Items(ctx, q)
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, its)
+	case "create":
+		var partials item
+		if err := readJSON(stdin, &partials); err != nil {
+			return err
+		}
+		it, err := api.Create(ctx, partials)
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, it)
+	case "update":
+		if len(args) != 2 {
+			return errUsage
+		}
+		var partials item
+		if err := readJSON(stdin, &partials); err != nil {
+			return err
+		}
+		it, err := api.Update(ctx, args[1], partials)
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, it)
+	case "delete":
+		if len(args) != 2 {
+			return errUsage
+		}
+		return api.Delete(ctx, args[1])
+	default:
+		return errUsage
+	}
+}
+
+// filterFlag collects repeated -filter field=value flags
+type filterFlag map[string]string
+
+func (f filterFlag) String() string {
+	return fmt.Sprint(map[string]string(f))
+}
+
+func (f filterFlag) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("filter %q is not in field=value format", s)
+	}
+	f[k] = v
+	return nil
+}
+
+func export(ctx context.Context, api itemsAPI, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "", "csv, json or xml rendered by the server instead of JSON items, v9 only")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+	api.CollectionName = fs.Arg(0)
+	if *format != "" {
+		return api.Export(ctx, stdout, directusapi.ExportFormat(*format), directusapi.Limit(-1))
+	}
+	its, err := api.Items(ctx, directusapi.Limit(-1))
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, its)
+}
+
+func importItems(ctx context.Context, api itemsAPI, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	api.CollectionName = args[0]
+	var its []item
+	if err := readJSON(stdin, &its); err != nil {
+		return err
+	}
+	for i, it := range its {
+		if _, err := api.Create(ctx, it); err != nil {
+			return fmt.Errorf("import item %d: %w", i, err)
+		}
+	}
+	_, err := fmt.Fprintf(stdout, "imported %d items\n", len(its))
+	return err
+}
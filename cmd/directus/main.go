@@ -0,0 +1,195 @@
+// Command directus is a command line client for Directus built on top of the directusapi package.
+//
+// Usage:
+//
+//	directus [-url URL] [-token TOKEN] [-token-file FILE] [-version 8|9] <command> [arguments]
+//
+// The commands are:
+//
+//	login -email EMAIL -password PASSWORD   print a new access token, save the session to the token file
+//	items get COLLECTION ID                 print a single item
+//	items list COLLECTION [flags]           print items matching the flags
+//	items create COLLECTION                 create an item read from stdin
+//	items update COLLECTION ID              update an item with partials read from stdin
+//	items delete COLLECTION ID              delete an item
+//	export [-format FORMAT] COLLECTION      print all items of a collection, as csv, json or xml by v9
+//	import COLLECTION                       create all items of a JSON array read from stdin
+//	dump [-files]                           write a gzipped tar archive of all collections to stdout
+//	restore                                 restore an archive written by dump read from stdin
+//	schema snapshot                         print the schema of the instance
+//	repl COLLECTION                         build queries interactively and inspect their results
+//
+// The url and token default to DIRECTUS_URL and DIRECTUS_TOKEN environment variables.
+// The token file defaults to DIRECTUS_TOKEN_FILE, it's encrypted by the base64 encoded AES key
+// of DIRECTUS_TOKEN_KEY. Without a token, commands use the session saved by login and renew it.
+// Items are read and written as JSON.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/antoniobuconjic/directusapi"
+	"github.com/antoniobuconjic/directusapi/tokenstore"
+)
+
+type item = map[string]any
+
+type itemsAPI = directusapi.API[item, item, string]
+
+var errUsage = errors.New("invalid usage, see go doc github.com/antoniobuconjic/directusapi/cmd/directus")
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "directus:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("directus", flag.ContinueOnError)
+	rawURL := fs.String("url", os.Getenv("DIRECTUS_URL"), "url of the Directus instance including the project, e.g. http://localhost:8080/_")
+	token := fs.String("token", os.Getenv("DIRECTUS_TOKEN"), "bearer token")
+	tokenFile := fs.String("token-file", os.Getenv("DIRECTUS_TOKEN_FILE"), "encrypted file of the session saved by login")
+	version := fs.Int("version", 8, "major version of the Directus instance")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	api, err := newAPI(*rawURL, *token, *version)
+	if err != nil {
+		return err
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		return errUsage
+	}
+	var store directusapi.TokenStore
+	if *tokenFile != "" {
+		if store, err = tokenStore(*tokenFile); err != nil {
+			return err
+		}
+		if *token == "" && args[0] != "login" {
+			if api.Auth, err = api.NewStoredAuthManager(ctx, store); err != nil {
+				return err
+			}
+		}
+	}
+	switch args[0] {
+	case "login":
+		return login(ctx, api, store, args[1:], stdout)
+	case "items":
+		return items(ctx, api, args[1:], stdin, stdout)
+	case "export":
+		return export(ctx, api, args[1:], stdout)
+	case "import":
+		return importItems(ctx, api, args[1:], stdin, stdout)
+	case "dump":
+		return dump(ctx, api, args[1:], stdout)
+	case "restore":
+		if len(args) != 1 {
+			return errUsage
+		}
+		return api.RestoreProject(ctx, stdin)
+	case "schema":
+		return schema(ctx, api, args[1:], stdout)
+	case "repl":
+		return repl(ctx, api, args[1:], stdin, stdout)
+	default:
+		return errUsage
+	}
+}
+
+func newAPI(rawURL, token string, version int) (itemsAPI, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return itemsAPI{}, fmt.Errorf("invalid url %q", rawURL)
+	}
+	v := directusapi.V8
+	switch version {
+	case 8:
+	case 9:
+		v = directusapi.V9
+	default:
+		return itemsAPI{}, fmt.Errorf("unsupported version %d", version)
+	}
+	return itemsAPI{
+		Scheme:      u.Scheme,
+		Host:        u.Host,
+		Namespace:   strings.Trim(u.Path, "/"),
+		BearerToken: token,
+		HTTPClient:  http.DefaultClient,
+		Version:     v,
+	}, nil
+}
+
+func tokenStore(path string) (tokenstore.Store, error) {
+	key, err := base64.StdEncoding.DecodeString(os.Getenv("DIRECTUS_TOKEN_KEY"))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("DIRECTUS_TOKEN_KEY has to be a base64 encoded AES key")
+	}
+	return tokenstore.FileStore{Path: path, Key: key}, nil
+}
+
+func login(ctx context.Context, api itemsAPI, store tokenstore.Store, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	email := fs.String("email", "", "user email")
+	password := fs.String("password", "", "user password")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	tokens, err := api.Login(ctx, *email, *password, "")
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		if err := store.Save(ctx, tokens); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(stdout, tokens.AccessToken)
+	return err
+}
+
+func dump(ctx context.Context, api itemsAPI, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	files := fs.Bool("files", false, "include files, supported only by v9")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return api.DumpProject(ctx, stdout, directusapi.DumpOptions{Files: *files})
+}
+
+func schema(ctx context.Context, api itemsAPI, args []string, stdout io.Writer) error {
+	if len(args) != 1 || args[0] != "snapshot" {
+		return errUsage
+	}
+	snapshot, err := api.SchemaSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, snapshot)
+}
+
+func readJSON(r io.Reader, dest any) error {
+	if err := json.NewDecoder(r).Decode(dest); err != nil {
+		return fmt.Errorf("decode input: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request received by newTestServer
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// newTestServer serves items of a v8 instance and records requests
+func newTestServer(t *testing.T) (string, *[]recordedRequest) {
+	var reqs []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		reqs = append(reqs, recordedRequest{r.Method, r.URL.Path, r.URL.RawQuery, string(body)})
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/_/items/fruits":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"kiwi"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"id":"1","name":"kiwi"}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/_", &reqs
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv("DIRECTUS_TOKEN_FILE", "")
+	item := "{\n  \"id\": \"1\",\n  \"name\": \"kiwi\"\n}\n"
+	items := "[\n  {\n    \"id\": \"1\",\n    \"name\": \"kiwi\"\n  }\n]\n"
+
+	tests := map[string]struct {
+		args   []string
+		stdin  string
+		out    string
+		reqs   []recordedRequest
+		errMsg string
+	}{
+		"get": {
+			args: []string{"items", "get", "fruits", "1"},
+			out:  item,
+			reqs: []recordedRequest{{"GET", "/_/items/fruits/1", "fields=%2A", ""}},
+		},
+		"list": {
+			args: []string{"items", "list", "fruits", "-filter", "name=kiwi", "-sort", "-name", "-limit", "2"},
+			out:  items,
+			reqs: []recordedRequest{{"GET", "/_/items/fruits", "fields=%2A&filter%5Bname%5D%5Beq%5D=kiwi&limit=2&offset=0&sort=-name", ""}},
+		},
+		"create": {
+			args:  []string{"items", "create", "fruits"},
+			stdin: `{"name":"kiwi"}`,
+			out:   item,
+			reqs:  []recordedRequest{{"POST", "/_/items/fruits", "fields=%2A", `{"name":"kiwi"}`}},
+		},
+		"update": {
+			args:  []string{"items", "update", "fruits", "1"},
+			stdin: `{"name":"kiwi"}`,
+			out:   item,
+			reqs:  []recordedRequest{{"PATCH", "/_/items/fruits/1", "fields=%2A", `{"name":"kiwi"}`}},
+		},
+		"delete": {
+			args: []string{"items", "delete", "fruits", "1"},
+			reqs: []recordedRequest{{"DELETE", "/_/items/fruits/1", "", ""}},
+		},
+		"export": {
+			args: []string{"export", "fruits"},
+			out:  items,
+			reqs: []recordedRequest{{"GET", "/_/items/fruits", "fields=%2A&limit=-1", ""}},
+		},
+		"import": {
+			args:  []string{"import", "fruits"},
+			stdin: `[{"name":"kiwi"},{"name":"lime"}]`,
+			out:   "imported 2 items\n",
+			reqs: []recordedRequest{
+				{"POST", "/_/items/fruits", "fields=%2A", `{"name":"kiwi"}`},
+				{"POST", "/_/items/fruits", "fields=%2A", `{"name":"lime"}`},
+			},
+		},
+		"import invalid input": {
+			args:   []string{"import", "fruits"},
+			stdin:  `{"name":"kiwi"}`,
+			errMsg: "decode input",
+		},
+		"export format by v8": {
+			args:   []string{"export", "-format", "csv", "fruits"},
+			errMsg: "supported only by v9",
+		},
+		"no command":     {args: []string{}, errMsg: errUsage.Error()},
+		"unknown":        {args: []string{"fruits"}, errMsg: errUsage.Error()},
+		"missing id":     {args: []string{"items", "get", "fruits"}, errMsg: errUsage.Error()},
+		"invalid filter": {args: []string{"items", "list", "fruits", "-filter", "kiwi"}, errMsg: "field=value"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, reqs := newTestServer(t)
+			var stdout bytes.Buffer
+			args := append([]string{"-url", u, "-token", "t0k3n"}, tt.args...)
+			err := run(context.Background(), args, strings.NewReader(tt.stdin), &stdout)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, stdout.String())
+			assert.Equal(t, tt.reqs, *reqs)
+		})
+	}
+}
+
+func TestNewAPI(t *testing.T) {
+	api, err := newAPI("https://example.com/project", "t0k3n", 9)
+	require.NoError(t, err)
+	assert.Equal(t, "https", api.Scheme)
+	assert.Equal(t, "example.com", api.Host)
+	assert.Equal(t, "project", api.Namespace)
+
+	_, err = newAPI("example.com", "", 8)
+	assert.Error(t, err)
+	_, err = newAPI("https://example.com", "", 7)
+	assert.Error(t, err)
+}
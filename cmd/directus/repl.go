@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/antoniobuconjic/directusapi"
+)
+
+const replHelp = `Build a query line by line, every filter is added to the current query.
+Quote values containing spaces with "double" or 'single' quotes, \" escapes a quote in double quotes.
+
+  eq|neq|in|contains|lt|lte|gt|gte FIELD VALUE   add a filter
+  between FIELD FROM TO                          add a between filter
+  null|nnull FIELD                               add a null check
+  sort FIELD                                     sort ascending, prefix FIELD with - for descending
+  limit N | offset N                             paginate
+  show                                           print the generated url and params
+  run                                            print the generated url, params and items
+  reset                                          start a new query
+  help                                           print this help
+  quit                                           exit
+`
+
+// repl runs an interactive query debugger over a single collection
+func repl(ctx context.Context, api itemsAPI, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	api.CollectionName = args[0]
+
+	q := directusapi.None()
+	show := func() {
+		u := api.ItemsURL(q)
+		fmt.Fprintln(stdout, u)
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return
+		}
+		params := parsed.Query()
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(stdout, "  %s = %s\n", k, params.Get(k))
+		}
+	}
+
+	fmt.Fprint(stdout, replHelp)
+	scanner := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, "> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		fields, err := splitArgs(scanner.Text())
+		if err != nil {
+			fmt.Fprintln(stdout, "error:", err)
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		cmd, params := fields[0], fields[1:]
+
+		switch {
+		case cmd == "quit" || cmd == "exit":
+			return nil
+		case cmd == "help":
+			fmt.Fprint(stdout, replHelp)
+		case cmd == "reset":
+			q = directusapi.None()
+		case cmd == "show":
+			show()
+		case cmd == "run":
+			show()
+			var its []item
+			its, err = api.Items(ctx, q)
+			if err == nil {
+				fmt.Fprintf(stdout, "%d items\n", len(its))
+				err = writeJSON(stdout, its)
+			}
+		case len(params) == 2 && cmd == "eq":
+			q = q.Eq(params[0], params[1])
+		case len(params) == 2 && cmd == "neq":
+			q = q.Neq(params[0], params[1])
+		case len(params) == 2 && cmd == "in":
+			q = q.In(params[0], params[1])
+		case len(params) == 2 && cmd == "contains":
+			q = q.Contains(params[0], params[1])
+		case len(params) == 2 && cmd == "lt":
+			q = q.Lt(params[0], params[1])
+		case len(params) == 2 && cmd == "lte":
+			q = q.Lte(params[0], params[1])
+		case len(params) == 2 && cmd == "gt":
+			q = q.Gt(params[0], params[1])
+		case len(params) == 2 && cmd == "gte":
+			q = q.Gte(params[0], params[1])
+		case len(params) == 3 && cmd == "between":
+			q = q.Between(params[0], params[1], params[2])
+		case len(params) == 1 && cmd == "null":
+			q = q.Null(params[0])
+		case len(params) == 1 && cmd == "nnull":
+			q = q.Nnull(params[0])
+		case len(params) == 1 && cmd == "sort":
+			if strings.HasPrefix(params[0], "-") {
+				q = q.SortDesc(strings.TrimPrefix(params[0], "-"))
+			} else {
+				q = q.SortAsc(params[0])
+			}
+		case len(params) == 1 && (cmd == "limit" || cmd == "offset"):
+			var n int
+			n, err = strconv.Atoi(params[0])
+			if err == nil && cmd == "limit" {
+				q = q.Limit(n)
+			} else if err == nil {
+				q = q.Offset(n)
+			}
+		default:
+			err = fmt.Errorf("unknown command %q, type help for the list of commands", scanner.Text())
+		}
+		if err != nil {
+			fmt.Fprintln(stdout, "error:", err)
+		}
+	}
+}
+
+// splitArgs splits a line into arguments separated by spaces, arguments can be quoted
+// by double or single quotes to contain spaces, backslash escapes characters in double quotes
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote in %q", line)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := map[string][]string{
+		``:                            nil,
+		`  eq  name kiwi `:            {"eq", "name", "kiwi"},
+		`eq name "red kiwi"`:          {"eq", "name", "red kiwi"},
+		`eq name 'red kiwi'`:          {"eq", "name", "red kiwi"},
+		`eq name "say \"hi\""`:        {"eq", "name", `say "hi"`},
+		`eq name 'it''s'`:             {"eq", "name", "its"},
+		`eq name ""`:                  {"eq", "name", ""},
+		`between date "2022-01-01" x`: {"between", "date", "2022-01-01", "x"},
+		`contains note "a 'b' c"`:     {"contains", "note", "a 'b' c"},
+		`eq na"me x"y z`:              {"eq", "name xy", "z"},
+		"eq name\t\"tab\tinside\"\t ": {"eq", "name", "tab\tinside"},
+	}
+	for line, want := range tests {
+		got, err := splitArgs(line)
+		require.NoError(t, err, line)
+		assert.Equal(t, want, got, line)
+	}
+
+	for _, line := range []string{`eq name "kiwi`, `eq name 'kiwi`, `eq name "kiwi\`} {
+		_, err := splitArgs(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestREPL(t *testing.T) {
+	u, reqs := newTestServer(t)
+	api, err := newAPI(u, "t0k3n", 8)
+	require.NoError(t, err)
+
+	input := strings.Join([]string{
+		`eq name "red kiwi"`,
+		`eq color "dark`,
+		`limit x`,
+		`frobnicate`,
+		`sort -name`,
+		`show`,
+		`run`,
+		`quit`,
+	}, "\n")
+	var stdout bytes.Buffer
+	require.NoError(t, repl(context.Background(), api, []string{"fruits"}, strings.NewReader(input), &stdout))
+
+	out := stdout.String()
+	assert.Contains(t, out, `error: unterminated quote in "eq color \"dark"`)
+	assert.Contains(t, out, `error: strconv.Atoi: parsing "x": invalid syntax`)
+	assert.Contains(t, out, `error: unknown command "frobnicate"`)
+	assert.Contains(t, out, "  filter[name][eq] = red kiwi\n")
+	assert.Contains(t, out, "  sort = -name\n")
+	assert.Contains(t, out, "1 items\n")
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, "/_/items/fruits", (*reqs)[0].Path)
+	assert.Contains(t, (*reqs)[0].Query, "filter%5Bname%5D%5Beq%5D=red+kiwi")
+
+	assert.Equal(t, errUsage, repl(context.Background(), api, nil, strings.NewReader(""), &stdout))
+}
@@ -0,0 +1,109 @@
+package directusapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Coalescer shares one upstream request between identical Items queries started within Window,
+// e.g. by many widgets rendering the same list. Callers get their own copy of the items slice,
+// but the items themselves are shared. Share a Coalescer only between API instances with the same
+// credentials, queries are matched by their url, headers and the token of WithToken.
+type Coalescer struct {
+	// Window is how long the first query waits for identical ones, defaults to 5ms
+	Window time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+const defaultCoalesceWindow = 5 * time.Millisecond
+
+type coalescedCall struct {
+	done    chan struct{}
+	waiters int
+	cancel  context.CancelFunc
+	val     any
+	err     error
+}
+
+// do executes fn once for all callers with the same key, fn receives a context which is canceled
+// only when all callers gave up, the first caller's cancellation doesn't fail the others
+func (c *Coalescer) do(ctx context.Context, key string, clock Clock, fn func(ctx context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	if c.calls == nil {
+		c.calls = map[string]*coalescedCall{}
+	}
+	call, ok := c.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(detachedContext{ctx})
+		call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+		c.calls[key] = call
+		go c.run(callCtx, key, call, clock, fn)
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// nobody waits for the result anymore, following callers start over
+			call.cancel()
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Coalescer) run(ctx context.Context, key string, call *coalescedCall, clock Clock, fn func(ctx context.Context) (any, error)) {
+	defer call.cancel()
+	t := clock.NewTimer(orDefault(c.Window, defaultCoalesceWindow))
+	select {
+	case <-t.C():
+		call.val, call.err = fn(ctx)
+	case <-ctx.Done():
+		t.Stop()
+		call.err = ctx.Err()
+	}
+	c.mu.Lock()
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// coalesceKey identifies identical requests
+func coalesceKey(r request) string {
+	token, _ := r.ctx.Value(tokenCtxKey{}).(string)
+	return r.method + " " + r.url + "?" + encodeQuery(r.qv) + "\n" + encodeQuery(requestHeaders(r.ctx)) + "\n" + token
+}
+
+// detachedContext keeps values of its parent but not its cancellation and deadline
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
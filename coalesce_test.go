@@ -0,0 +1,58 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalescer(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"}]}`))
+	}))
+	api.Coalescer = &Coalescer{Window: 50 * time.Millisecond}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fruits, err := api.Items(context.Background(), Eq("name", "kiwi"))
+			assert.NoError(t, err)
+			assert.Len(t, fruits, 1)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls)
+
+	// different queries don't share requests
+	_, err := api.Items(context.Background(), Eq("name", "plum"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls)
+
+	t.Run("canceled caller", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error)
+		go func() {
+			_, err := api.Items(ctx, None())
+			first <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-first, context.Canceled)
+
+		fruits, err := api.Items(context.Background(), None())
+		require.NoError(t, err)
+		assert.Len(t, fruits, 1)
+		assert.EqualValues(t, 1, calls)
+	})
+}
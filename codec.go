@@ -0,0 +1,187 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Codec is a precomputed field list and JSON codec of a model, usually produced by a code generator.
+// Registered codecs are used instead of reflection, so production binaries avoid reflect on the hot path.
+// Marshal and Unmarshal are optional, encoding/json is used when they are nil.
+type Codec[T any] struct {
+	// Fields requested when T is a read model, it has to match the fields computed by reflection
+	Fields    []string
+	Marshal   func(T) ([]byte, error)
+	Unmarshal func([]byte, *T) error
+}
+
+// codecs holds registered codecs keyed by (*T)(nil), so lookups don't need reflect
+var codecs sync.Map
+
+// RegisterCodec registers a codec of T for all API instances,
+// it's meant to be called from init functions of generated code.
+func RegisterCodec[T any](c Codec[T]) {
+	codecs.Store((*T)(nil), c)
+}
+
+// RegisterFields registers fields requested when R is a read model, so they aren't computed
+// by reflection. It keeps Marshal and Unmarshal of a codec registered for R,
+// tests should check the fields match the struct by VerifyFields.
+func RegisterFields[R any](fields []string) {
+	c, _ := lookupCodec[R]()
+	c.Fields = append([]string{}, fields...)
+	RegisterCodec(c)
+}
+
+// VerifyFields reports fields registered for R which differ from the fields computed by reflection,
+// it's meant to be called from tests to catch drift of the model and its registered fields
+func VerifyFields[R any]() error {
+	c, ok := lookupCodec[R]()
+	if !ok || c.Fields == nil {
+		return fmt.Errorf("fields of %T are not registered", *new(R))
+	}
+	computed := map[string]bool{}
+	for _, f := range reflectFields[R]() {
+		computed[f] = true
+	}
+	var unknown []string
+	for _, f := range c.Fields {
+		if !computed[f] {
+			unknown = append(unknown, f)
+		}
+		delete(computed, f)
+	}
+	var missing []string
+	for f := range computed {
+		missing = append(missing, f)
+	}
+	sort.Strings(missing)
+	if len(unknown) > 0 || len(missing) > 0 {
+		return fmt.Errorf("fields of %T differ from the struct, unknown: %v, missing: %v", *new(R), unknown, missing)
+	}
+	return nil
+}
+
+// fieldReflectionForbidden is set by ForbidFieldReflection
+var fieldReflectionForbidden int32
+
+// ForbidFieldReflection makes read models without registered fields panic instead of computing
+// their fields by reflection, so deployments which forbid reflection catch a missing registration
+// at startup. It's meant to be called from main after all init functions registered their fields.
+func ForbidFieldReflection() {
+	atomic.StoreInt32(&fieldReflectionForbidden, 1)
+}
+
+func lookupCodec[T any]() (Codec[T], bool) {
+	c, ok := codecs.Load((*T)(nil))
+	if !ok {
+		return Codec[T]{}, false
+	}
+	return c.(Codec[T]), true
+}
+
+// marshalBody encodes v with a registered codec, values without one are left to encoding/json
+func marshalBody[T any](v T) (any, error) {
+	c, ok := lookupCodec[T]()
+	if !ok || c.Marshal == nil {
+		if rv := reflect.ValueOf(&v).Elem(); hooked(rv.Type(), marshalerType) {
+			return encodeHooked(rv)
+		}
+		return v, nil
+	}
+	b, err := c.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+func unmarshalData[T any](data []byte, dest *T) error {
+	c, ok := lookupCodec[T]()
+	if !ok || c.Unmarshal == nil {
+		if rv := reflect.ValueOf(dest).Elem(); hooked(rv.Type(), unmarshalerType) {
+			return decodeHooked(data, rv)
+		}
+		return json.Unmarshal(data, dest)
+	}
+	return c.Unmarshal(data, dest)
+}
+
+// itemEnvelope is a response body of single item endpoints
+type itemEnvelope[T any] struct {
+	Data T
+}
+
+func (e *itemEnvelope[T]) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw.Data == nil {
+		return nil
+	}
+	return unmarshalData(raw.Data, &e.Data)
+}
+
+// parseCount parses a count of meta which is a number or a string of a number, it's 0 when it's missing
+func parseCount(raw json.RawMessage) (int, error) {
+	if raw == nil || string(raw) == "null" {
+		return 0, nil
+	}
+	return strconv.Atoi(strings.Trim(string(raw), `"`))
+}
+
+// itemsEnvelope is a response body of item collection endpoints
+type itemsEnvelope[T any] struct {
+	Data []T
+	Meta ItemsMeta
+}
+
+func (e *itemsEnvelope[T]) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Data []json.RawMessage `json:"data"`
+		Meta struct {
+			// counts are strings on some databases
+			TotalCount  json.RawMessage `json:"total_count"`
+			FilterCount json.RawMessage `json:"filter_count"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	var err error
+	if e.Meta.TotalCount, err = parseCount(raw.Meta.TotalCount); err != nil {
+		return fmt.Errorf("parse total count: %w", err)
+	}
+	if e.Meta.FilterCount, err = parseCount(raw.Meta.FilterCount); err != nil {
+		return fmt.Errorf("parse filter count: %w", err)
+	}
+	if raw.Data == nil {
+		return nil
+	}
+	if e.Data != nil && cap(e.Data) >= len(raw.Data) {
+		// capacity of a slice given by ItemsInto is reused, stale values are cleared
+		// because decoding into a struct keeps fields missing in the data
+		var zero T
+		e.Data = e.Data[:len(raw.Data)]
+		for i := range e.Data {
+			e.Data[i] = zero
+		}
+	} else {
+		e.Data = make([]T, len(raw.Data))
+	}
+	for i, d := range raw.Data {
+		if err := unmarshalData(d, &e.Data[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
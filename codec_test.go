@@ -0,0 +1,125 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+type codecFruit struct {
+	ID   int
+	Name string
+}
+
+func TestRegisteredCodec(t *testing.T) {
+	decoded, encoded := 0, 0
+	RegisterCodec(Codec[codecFruit]{
+		Fields: []string{"id", "name"},
+		Marshal: func(f codecFruit) ([]byte, error) {
+			encoded++
+			return []byte(`{"name":` + strconv.Quote(f.Name) + `}`), nil
+		},
+		Unmarshal: func(b []byte, f *codecFruit) error {
+			decoded++
+			var raw struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(b, &raw); err != nil {
+				return err
+			}
+			*f = codecFruit{raw.ID, raw.Name}
+			return nil
+		},
+	})
+
+	var reqs []*http.Request
+	var bodies []string
+	api := API[codecFruit, codecFruit, int]{
+		Scheme:         "http",
+		Host:           "localhost:8080",
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			reqs = append(reqs, r)
+			if r.Body != nil {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+			}
+			if r.Method == http.MethodGet {
+				return jsonResponse(http.StatusOK, `{"data":[{"id":1,"name":"kiwi"},{"id":2,"name":"plum"}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":{"id":3,"name":"fig"}}`), nil
+		})},
+	}
+
+	fruits, err := api.Items(context.Background(), None())
+	require.NoError(t, err)
+	assert.Equal(t, []codecFruit{{1, "kiwi"}, {2, "plum"}}, fruits)
+	assert.Equal(t, "id,name", reqs[0].URL.Query().Get("fields"))
+
+	fig, err := api.Insert(context.Background(), codecFruit{Name: "fig"})
+	require.NoError(t, err)
+	assert.Equal(t, codecFruit{3, "fig"}, fig)
+	assert.Equal(t, []string{`{"name":"fig"}`}, bodies)
+
+	assert.Equal(t, 3, decoded)
+	assert.Equal(t, 1, encoded)
+}
+
+type registeredFruit struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Origin struct {
+		Country string `json:"country"`
+	} `json:"origin"`
+}
+
+type unregisteredFruit struct {
+	ID int `json:"id"`
+}
+
+func TestRegisterFields(t *testing.T) {
+	require.Error(t, VerifyFields[registeredFruit]())
+
+	RegisterFields[registeredFruit]([]string{"id", "name", "origin.country"})
+	require.NoError(t, VerifyFields[registeredFruit]())
+	assert.Equal(t, []string{"id", "name", "origin.country"}, API[registeredFruit, registeredFruit, int]{}.jsonFieldsR())
+
+	RegisterFields[registeredFruit]([]string{"id", "title"})
+	err := VerifyFields[registeredFruit]()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown: [title], missing: [name origin.country]")
+
+	ForbidFieldReflection()
+	t.Cleanup(func() {
+		atomic.StoreInt32(&fieldReflectionForbidden, 0)
+	})
+	assert.Equal(t, []string{"id", "title"}, API[registeredFruit, registeredFruit, int]{}.jsonFieldsR())
+	assert.Panics(t, func() {
+		API[unregisteredFruit, unregisteredFruit, int]{}.jsonFieldsR()
+	})
+}
@@ -0,0 +1,92 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Collection is the interface of item operations of API, layers consuming a collection
+// can depend on it and use MockCollection in their tests
+type Collection[R, W any, PK PrimaryKey] interface {
+	Insert(ctx context.Context, item W) (R, error)
+	Create(ctx context.Context, partials map[string]any) (R, error)
+	GetByID(ctx context.Context, id PK) (R, error)
+	Update(ctx context.Context, id PK, partials map[string]any) (R, error)
+	Set(ctx context.Context, id PK, item W) (R, error)
+	Delete(ctx context.Context, id PK) error
+	Items(ctx context.Context, q Query) ([]R, error)
+}
+
+var _ Collection[struct{}, struct{}, int] = API[struct{}, struct{}, int]{}
+
+// MockCollection implements Collection by its functions, calls of functions which are not set fail.
+// ItemsFunc receives the query serialized to Directus v9 params, e.g. filter[name][_eq].
+type MockCollection[R, W any, PK PrimaryKey] struct {
+	InsertFunc  func(ctx context.Context, item W) (R, error)
+	CreateFunc  func(ctx context.Context, partials map[string]any) (R, error)
+	GetByIDFunc func(ctx context.Context, id PK) (R, error)
+	UpdateFunc  func(ctx context.Context, id PK, partials map[string]any) (R, error)
+	SetFunc     func(ctx context.Context, id PK, item W) (R, error)
+	DeleteFunc  func(ctx context.Context, id PK) error
+	ItemsFunc   func(ctx context.Context, params map[string]string) ([]R, error)
+}
+
+var _ Collection[struct{}, struct{}, int] = MockCollection[struct{}, struct{}, int]{}
+
+func notMocked(method string) error {
+	return fmt.Errorf("%s is not mocked", method)
+}
+
+func (m MockCollection[R, W, PK]) Insert(ctx context.Context, item W) (R, error) {
+	if m.InsertFunc == nil {
+		var empty R
+		return empty, notMocked("Insert")
+	}
+	return m.InsertFunc(ctx, item)
+}
+
+func (m MockCollection[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R, error) {
+	if m.CreateFunc == nil {
+		var empty R
+		return empty, notMocked("Create")
+	}
+	return m.CreateFunc(ctx, partials)
+}
+
+func (m MockCollection[R, W, PK]) GetByID(ctx context.Context, id PK) (R, error) {
+	if m.GetByIDFunc == nil {
+		var empty R
+		return empty, notMocked("GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+func (m MockCollection[R, W, PK]) Update(ctx context.Context, id PK, partials map[string]any) (R, error) {
+	if m.UpdateFunc == nil {
+		var empty R
+		return empty, notMocked("Update")
+	}
+	return m.UpdateFunc(ctx, id, partials)
+}
+
+func (m MockCollection[R, W, PK]) Set(ctx context.Context, id PK, item W) (R, error) {
+	if m.SetFunc == nil {
+		var empty R
+		return empty, notMocked("Set")
+	}
+	return m.SetFunc(ctx, id, item)
+}
+
+func (m MockCollection[R, W, PK]) Delete(ctx context.Context, id PK) error {
+	if m.DeleteFunc == nil {
+		return notMocked("Delete")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m MockCollection[R, W, PK]) Items(ctx context.Context, q Query) ([]R, error) {
+	if m.ItemsFunc == nil {
+		return nil, notMocked("Items")
+	}
+	return m.ItemsFunc(ctx, q.asKeyValue(V9))
+}
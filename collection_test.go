@@ -0,0 +1,38 @@
+package directusapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fruitNames is a consumer of a collection depending only on the interface
+func fruitNames(ctx context.Context, c Collection[FruitR, FruitW, int]) ([]string, error) {
+	fruits, err := c.Items(ctx, Eq("status", "published"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(fruits))
+	for i, f := range fruits {
+		names[i] = f.Name
+	}
+	return names, nil
+}
+
+func TestMockCollection(t *testing.T) {
+	mock := MockCollection[FruitR, FruitW, int]{
+		ItemsFunc: func(ctx context.Context, params map[string]string) ([]FruitR, error) {
+			assert.Equal(t, "published", params["filter[status][_eq]"])
+			return []FruitR{{Name: "kiwi"}, {Name: "lime"}}, nil
+		},
+	}
+
+	names, err := fruitNames(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kiwi", "lime"}, names)
+
+	_, err = mock.GetByID(context.Background(), 1)
+	assert.EqualError(t, err, "GetByID is not mocked")
+}
@@ -0,0 +1,128 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompatibilityWarning is reported to OnWarning when the server runs a newer Directus major
+// than Version of the API with known incompatibilities, it lists those of the newer majors.
+// With ServerInfo the server is checked before the first request of the API.
+type CompatibilityWarning struct {
+	ServerVersion     string
+	ClientMajor       int
+	ServerMajor       int
+	Incompatibilities []string
+}
+
+func (w *CompatibilityWarning) Error() string {
+	msg := fmt.Sprintf("directus %s is newer than major %d supported by the client", w.ServerVersion, w.ClientMajor)
+	if len(w.Incompatibilities) == 0 {
+		return msg
+	}
+	return msg + ": " + strings.Join(w.Incompatibilities, "; ")
+}
+
+// knownIncompatibilities lists changes of majors breaking clients built for older majors
+var knownIncompatibilities = map[int][]string{
+	9: {
+		"items are served without the project namespace",
+		"/auth/authenticate was replaced by /auth/login",
+		"filter operators are prefixed by underscore",
+		"system fields are date_created and date_updated instead of created_on and modified_on",
+	},
+	11: {
+		"permissions are attached to policies instead of roles",
+		"admin_access and app_access moved from roles to policies",
+	},
+}
+
+// ServerVersion retrieves the version of the Directus server, it's empty when the token
+// isn't allowed to see it. When the server runs a newer major than Version of the API
+// a *CompatibilityWarning is reported to OnWarning, once per server of ServerInfo when it's set.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/server.html#get-server-info
+// https://v8.docs.directus.io/api/server.html#information
+func (d API[R, W, PK]) ServerVersion(ctx context.Context) (string, error) {
+	info, err := d.serverInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	d.checkCompatibility(info.version())
+	return info.version(), nil
+}
+
+// serverInfo is a subset of /server/info of all majors
+type serverInfo struct {
+	Directus  json.RawMessage `json:"directus"`
+	Version   string          `json:"version"`
+	WebSocket json.RawMessage `json:"websocket"`
+	Uploads   json.RawMessage `json:"uploads"`
+	// MaxPayloadSize is set by servers advertising their MAX_PAYLOAD_SIZE in bytes
+	MaxPayloadSize json.RawMessage `json:"maxPayloadSize"`
+}
+
+func (i serverInfo) version() string {
+	// v8 returns the version as a string, v9 as an object
+	var v8Version string
+	var v9Version struct {
+		Version string `json:"version"`
+	}
+	switch {
+	case json.Unmarshal(i.Directus, &v8Version) == nil:
+		return v8Version
+	case json.Unmarshal(i.Directus, &v9Version) == nil && v9Version.Version != "":
+		return v9Version.Version
+	}
+	return i.Version
+}
+
+func (d API[R, W, PK]) serverInfo(ctx context.Context) (serverInfo, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/server/info",
+		nil,
+		nil,
+	}
+	var respBody struct {
+		Data serverInfo `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return serverInfo{}, fmt.Errorf("execute server info request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+func (d API[R, W, PK]) checkCompatibility(version string) {
+	serverMajor, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return
+	}
+	clientMajor := 9
+	if d.Version == V8 {
+		clientMajor = 8
+	}
+	if serverMajor <= clientMajor {
+		return
+	}
+	w := &CompatibilityWarning{
+		ServerVersion: version,
+		ClientMajor:   clientMajor,
+		ServerMajor:   serverMajor,
+	}
+	for major := clientMajor + 1; major <= serverMajor; major++ {
+		w.Incompatibilities = append(w.Incompatibilities, knownIncompatibilities[major]...)
+	}
+	// newer majors without known incompatibilities work as the supported one
+	if len(w.Incompatibilities) == 0 || !d.ServerInfo.warnOnce(d.baseURL()) {
+		return
+	}
+	d.warn(w)
+}
@@ -0,0 +1,96 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerVersion(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/server/info", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{"directus":{"version":"11.1.0"}}}`))
+	}))
+	api.Version = V9
+	api.ServerInfo = &ServerInfoCache{}
+	var warnings []error
+	api.OnWarning = func(err error) {
+		warnings = append(warnings, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		version, err := api.ServerVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "11.1.0", version)
+	}
+	require.Len(t, warnings, 1)
+	var w *CompatibilityWarning
+	require.True(t, errors.As(warnings[0], &w))
+	assert.Equal(t, 9, w.ClientMajor)
+	assert.Equal(t, 11, w.ServerMajor)
+	assert.Equal(t, knownIncompatibilities[11], w.Incompatibilities)
+}
+
+func TestServerVersionV8(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"directus":"8.8.1"}}`))
+	}))
+	api.OnWarning = func(err error) {
+		t.Errorf("unexpected warning: %v", err)
+	}
+
+	version, err := api.ServerVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.1", version)
+}
+
+func TestCompatibilityWarningFirstRequest(t *testing.T) {
+	var infoCalls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/server/info" {
+			infoCalls++
+			_, _ = w.Write([]byte(`{"data":{"directus":"9.1.0"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	})
+	// every API sharing no cache is warned on its own
+	var warnings [2][]error
+	for i := range warnings {
+		i := i
+		api := newTestAPI(t, handler)
+		api.ServerInfo = &ServerInfoCache{}
+		api.OnWarning = func(err error) {
+			warnings[i] = append(warnings[i], err)
+		}
+		for j := 0; j < 2; j++ {
+			_, err := api.GetByID(context.Background(), 1)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, 2, infoCalls)
+	for _, w := range warnings {
+		require.Len(t, w, 1)
+		var compatErr *CompatibilityWarning
+		require.True(t, errors.As(w[0], &compatErr))
+		assert.Equal(t, knownIncompatibilities[9], compatErr.Incompatibilities)
+	}
+}
+
+func TestCompatibilityNewerMajorWithoutIncompatibilities(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"directus":{"version":"10.13.1"}}}`))
+	}))
+	api.Version = V9
+	api.OnWarning = func(err error) {
+		t.Errorf("unexpected warning: %v", err)
+	}
+
+	version, err := api.ServerVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "10.13.1", version)
+}
@@ -0,0 +1,62 @@
+package directusapi
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// DebugFilter enables dumps of requests and responses only for selected requests,
+// every filter which is not empty has to match
+type DebugFilter struct {
+	// Collections limits dumps to APIs of these collections
+	Collections []string
+	// Methods limits dumps to these HTTP methods, e.g. POST
+	Methods []string
+	// StatusClasses limits dumps to responses of these classes, e.g. 4 and 5 for non-2xx.
+	// Requests which failed without a response are always dumped.
+	StatusClasses []int
+	// Output receives the dumps, defaults to stdout
+	Output io.Writer
+}
+
+func (a *API[R, W, PK]) debugRequest(method string) bool {
+	if a.debug {
+		return true
+	}
+	f := a.Debug
+	if f == nil {
+		return false
+	}
+	return (len(f.Collections) == 0 || containsString(f.Collections, a.CollectionName)) &&
+		(len(f.Methods) == 0 || containsString(f.Methods, method))
+}
+
+func (a *API[R, W, PK]) debugStatus(status int) bool {
+	if a.debug || a.Debug == nil || len(a.Debug.StatusClasses) == 0 {
+		return true
+	}
+	for _, c := range a.Debug.StatusClasses {
+		if status/100 == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *API[R, W, PK]) printDump(kind string, dump []byte) {
+	var w io.Writer = os.Stdout
+	if a.Debug != nil && a.Debug.Output != nil {
+		w = a.Debug.Output
+	}
+	fmt.Fprintf(w, "--- %s start ---\n%s\n--- %s end ---\n", kind, dump, kind)
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
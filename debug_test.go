@@ -0,0 +1,40 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugFilter(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/items/fruits/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	var out bytes.Buffer
+	api.Debug = &DebugFilter{
+		Methods:       []string{http.MethodGet},
+		StatusClasses: []int{4, 5},
+		Output:        &out,
+	}
+	ctx := context.Background()
+
+	_, _ = api.GetByID(ctx, 1)
+	_ = api.Delete(ctx, 2)
+	assert.Empty(t, out.String())
+
+	_, _ = api.GetByID(ctx, 2)
+	assert.Contains(t, out.String(), "--- Request start ---\nGET /_/items/fruits/2")
+	assert.Contains(t, out.String(), "404 Not Found")
+
+	out.Reset()
+	api.Debug.Collections = []string{"users"}
+	_, _ = api.GetByID(ctx, 2)
+	assert.Empty(t, out.String())
+}
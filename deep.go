@@ -0,0 +1,55 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GetByIDDeep reads a single item by given ID with relations expanded to the given depth,
+// e.g. {"author": 2} returns all fields of the author and of items related to the author.
+// Fields of the read model under expanded relations are replaced by wildcards, so the read model
+// should hold expanded relations in maps or structs with the fields of interest.
+// Related lists of expanded relations are not limited, Directus v8 applies only the wildcards.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#fields
+// https://docs.directus.io/reference/query.html#deep
+func (d API[R, W, PK]) GetByIDDeep(ctx context.Context, id PK, depth map[string]int) (R, error) {
+	var empty R
+	relations := make([]string, 0, len(depth))
+	for rel, n := range depth {
+		if n < 1 {
+			return empty, fmt.Errorf("depth of %s has to be positive, got %d", rel, n)
+		}
+		relations = append(relations, rel)
+	}
+	sort.Strings(relations)
+
+	var fields []string
+	for _, f := range d.jsonFieldsR() {
+		if !underRelation(f, relations) {
+			fields = append(fields, f)
+		}
+	}
+	qv := map[string]string{}
+	for _, rel := range relations {
+		fields = append(fields, rel+strings.Repeat(".*", depth[rel]))
+		if d.Version == V9 {
+			qv[fmt.Sprintf("deep%s[_limit]", parseV9Path(rel))] = "-1"
+		}
+	}
+	qv["fields"] = strings.Join(fields, ",")
+	d.aliasParams(qv)
+	return d.getByID(ctx, id, qv)
+}
+
+func underRelation(field string, relations []string) bool {
+	for _, rel := range relations {
+		if field == rel || strings.HasPrefix(field, rel+".") {
+			return true
+		}
+	}
+	return false
+}
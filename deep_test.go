@@ -0,0 +1,35 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByIDDeep(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		assert.Equal(t, "/_/items/fruits/1", r.URL.Path)
+		fields := strings.Split(qv.Get("fields"), ",")
+		assert.Contains(t, fields, "name")
+		assert.Contains(t, fields, "lefield.*.*")
+		assert.Contains(t, fields, "poc.*")
+		for _, f := range fields {
+			assert.False(t, strings.HasPrefix(f, "lefield.") && f != "lefield.*.*", f)
+		}
+		assert.Equal(t, "-1", qv.Get("deep[lefield][_limit]"))
+		_, _ = w.Write([]byte(`{"data":{"id":1,"lefield":{"id":5}}}`))
+	}))
+	api.Version = V9
+
+	f, err := api.GetByIDDeep(context.Background(), 1, map[string]int{"lefield": 2, "poc": 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.ID)
+
+	_, err = api.GetByIDDeep(context.Background(), 1, map[string]int{"poc": 0})
+	assert.Error(t, err)
+}
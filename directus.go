@@ -2,10 +2,17 @@ package directusapi
 
 import (
 	"context"
+	"encoding"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Version int
@@ -32,22 +39,91 @@ type API[R, W any, PK PrimaryKey] struct {
 	HTTPClient     *http.Client
 	queryFields    []string
 	debug          bool
+	fixedToken     bool
 	Version        Version
+	// Lifecycle is optional, when set it tracks requests and background goroutines for Close
+	Lifecycle *Lifecycle
+	// TokenRefresher is optional, when set its access token is used instead of BearerToken
+	TokenRefresher *TokenRefresher
+	// Auth is optional, when set its access token is used instead of TokenRefresher and BearerToken
+	Auth *AuthManager
+	// TokenProvider is optional, when set it provides the token instead of Auth, TokenRefresher and BearerToken
+	TokenProvider TokenProvider
+	// Clock and Rand default to the system time and math/rand
+	Clock Clock
+	Rand  Rand
+	// MaxRetries is a number of retries of a failed request, retries are disabled by default
+	MaxRetries int
+	// RetryPolicy decides which failures are retried, defaults to DefaultRetryPolicy
+	RetryPolicy RetryPolicy
+	// RetryBackoff is a base of the exponential backoff between retries, defaults to 100ms
+	RetryBackoff time.Duration
+	// MaxPayloadSize should match MAX_PAYLOAD_SIZE of the server, bigger request bodies are reported
+	// as *PayloadSizeError instead of an opaque 413. When it's zero the limit advertised by the server
+	// in /server/info is used, see ServerInfo, the check is disabled when neither is set.
+	MaxPayloadSize int64
+	// StrictPayloadSize fails requests exceeding MaxPayloadSize, they are only reported to OnWarning otherwise
+	StrictPayloadSize bool
+	// StrictResponses fails responses of the expected status which carry Directus errors, e.g. of some
+	// proxied setups, with *ResponseError instead of decoding their missing data as zero values
+	StrictResponses bool
+	// OnWarning receives non-fatal problems detected by the client
+	OnWarning func(error)
+	// Schema is optional, when set filter values are validated against field types, see LoadSchema
+	Schema *CollectionSchema
+	// DefaultQuery is merged into every query of Items, e.g. Eq("status", "published").
+	// Filters of the same field and operator, sort and pagination of the query override the defaults.
+	DefaultQuery Query
+	// Tenant is optional, when set every request is scoped to the tenant of the context, see WithTenant
+	Tenant *TenantScope
+	// Owner is optional, when set inserted items get the owner field set to the current user
+	Owner *Ownership
+	// FlowSignature is optional, when set requests of TriggerFlow are signed
+	FlowSignature *FlowSignature
+	// Debug is optional, when set requests matching it are dumped
+	Debug *DebugFilter
+	// Artifacts is optional, when set failed requests are persisted for reproduction
+	Artifacts *ArtifactStore
+	// ServerInfo is optional, when set /server/info is retrieved before the first request and cached
+	ServerInfo *ServerInfoCache
+	// Metadata is optional, when set collections and fields metadata are cached
+	Metadata *MetadataCache
+	// RateLimiter is optional, when set requests are slowed down as the rate limit budget shrinks
+	RateLimiter *RateLimiter
+	// LiveConfig is optional, when set its host, token and rate limit threshold override those of the API
+	LiveConfig *LiveConfig
+	// Guard is optional, when set only operations of collections it allows are sent
+	Guard *Guard
+	// PostProcess hooks are applied in order to every decoded read item, see ItemHook
+	PostProcess []ItemHook[R]
+	// Validator is optional, when set written items are validated before they are sent
+	Validator *Validator[W]
+	// Coalescer is optional, when set identical concurrent Items queries share one request
+	Coalescer *Coalescer
 }
 
+// DefaultMaxPayloadSize is the default MAX_PAYLOAD_SIZE of Directus
+const DefaultMaxPayloadSize = 1 << 20
+
 // CreateToken uses provided credentials to generate server token
 //
 // Related Directus reference:
 // https://v8.docs.directus.io/api/authentication.html#retrieve-a-temporary-access-token
 func (d API[R, W, PK]) CreateToken(ctx context.Context, email, password string) (string, error) {
-	u := fmt.Sprintf("%s://%s/%s/auth/authenticate", d.Scheme, d.Host, d.Namespace)
+	return d.authenticate(ctx, email, password, "")
+}
+
+func (d API[R, W, PK]) authenticate(ctx context.Context, email, password, otp string) (string, error) {
+	u := d.baseURL() + "/auth/authenticate"
 
 	body := struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		OTP      string `json:"otp,omitempty"`
 	}{
 		email,
 		password,
+		otp,
 	}
 
 	req := request{
@@ -77,21 +153,30 @@ func (d API[R, W, PK]) CreateToken(ctx context.Context, email, password string)
 // https://v8.docs.directus.io/api/items.html#create-an-item
 func (d API[R, W, PK]) Insert(ctx context.Context, item W) (R, error) {
 	var empty R
-	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	u := d.itemsURL()
+	if err := d.Validator.item(ctx, &item); err != nil {
+		return empty, err
+	}
+	body, err := marshalBody(item)
+	if err != nil {
+		return empty, fmt.Errorf("marshal insert body: %w", err)
+	}
+	if body, err = d.scopeBody(ctx, body); err != nil {
+		return empty, err
+	}
+	if body, err = d.ownBody(ctx, body); err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPost,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
-		item,
-	}
-	var respBody struct {
-		Data R `json:"data"`
+		d.fieldParams(),
+		body,
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var respBody itemEnvelope[R]
+	err = d.executeRequest(req, http.StatusOK, &respBody)
 	if err != nil {
 		return empty, fmt.Errorf("execute insert request: %w", err)
 	}
@@ -104,22 +189,28 @@ func (d API[R, W, PK]) Insert(ctx context.Context, item W) (R, error) {
 // https://v8.docs.directus.io/api/items.html#create-an-item
 func (d API[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R, error) {
 	var empty R
-	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	u := d.itemsURL()
+	if err := d.Validator.partials(ctx, partials); err != nil {
+		return empty, err
+	}
+	body, err := d.scopeBody(ctx, withoutUnchangedRelations(partials))
+	if err != nil {
+		return empty, err
+	}
+	if body, err = d.ownBody(ctx, body); err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPost,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
-		partials,
+		d.fieldParams(),
+		body,
 	}
 
-	var respBody struct {
-		Data R `json:"data"`
-	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var respBody itemEnvelope[R]
+	err = d.executeRequest(req, http.StatusOK, &respBody)
 	if err != nil {
 		return empty, fmt.Errorf("execute create request: %w", err)
 	}
@@ -132,23 +223,35 @@ func (d API[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R,
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#retrieve-an-item
 func (d API[R, W, PK]) GetByID(ctx context.Context, id PK) (R, error) {
-	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	d = d.withContextFields(ctx)
+	return d.getByID(ctx, id, d.fieldParams())
+}
+
+func (d API[R, W, PK]) getByID(ctx context.Context, id PK, qv map[string]string) (R, error) {
+	d.localize(ctx, qv)
+	if _, scoped, err := d.tenant(ctx); err != nil {
+		var empty R
+		return empty, err
+	} else if scoped {
+		return d.getScoped(ctx, id, qv)
+	}
+	u, err := d.itemURL(id)
+	if err != nil {
+		var empty R
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodGet,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
+		qv,
 		nil,
 	}
 
-	var respBody struct {
-		Data R `json:"data"`
-	}
+	var respBody itemEnvelope[R]
 	var empty R
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.executeRequest(req, http.StatusOK, &respBody)
 	if err != nil {
 		return empty, fmt.Errorf("execute get by id request: %w", err)
 	}
@@ -161,22 +264,31 @@ func (d API[R, W, PK]) GetByID(ctx context.Context, id PK) (R, error) {
 // https://v8.docs.directus.io/api/items.html#update-an-item
 func (d API[R, W, PK]) Update(ctx context.Context, id PK, partials map[string]any) (R, error) {
 	var empty R
-	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	u, err := d.itemURL(id)
+	if err != nil {
+		return empty, err
+	}
+	if err := d.checkScope(ctx, id); err != nil {
+		return empty, err
+	}
+	if err := d.Validator.partials(ctx, partials); err != nil {
+		return empty, err
+	}
+	body, err := d.scopeBody(ctx, withoutUnchangedRelations(partials))
+	if err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPatch,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
-		partials,
+		d.fieldParams(),
+		body,
 	}
 
-	var respBody struct {
-		Data R `json:"data"`
-	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var respBody itemEnvelope[R]
+	err = d.executeRequest(req, http.StatusOK, &respBody)
 	if err != nil {
 		return empty, fmt.Errorf("execute update request: %w", err)
 	}
@@ -189,22 +301,34 @@ func (d API[R, W, PK]) Update(ctx context.Context, id PK, partials map[string]an
 // https://v8.docs.directus.io/api/items.html#update-an-item
 func (d API[R, W, PK]) Set(ctx context.Context, id PK, item W) (R, error) {
 	var empty R
-	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	u, err := d.itemURL(id)
+	if err != nil {
+		return empty, err
+	}
+	if err := d.checkScope(ctx, id); err != nil {
+		return empty, err
+	}
+	if err := d.Validator.item(ctx, &item); err != nil {
+		return empty, err
+	}
+	body, err := marshalBody(item)
+	if err != nil {
+		return empty, fmt.Errorf("marshal set body: %w", err)
+	}
+	if body, err = d.scopeBody(ctx, body); err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPatch,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
-		item,
+		d.fieldParams(),
+		body,
 	}
 
-	var respBody struct {
-		Data R `json:"data"`
-	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var respBody itemEnvelope[R]
+	err = d.executeRequest(req, http.StatusOK, &respBody)
 	if err != nil {
 		return empty, fmt.Errorf("execute set request: %w", err)
 	}
@@ -216,7 +340,13 @@ func (d API[R, W, PK]) Set(ctx context.Context, id PK, item W) (R, error) {
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#update-an-item
 func (d API[R, W, PK]) Delete(ctx context.Context, id PK) error {
-	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	u, err := d.itemURL(id)
+	if err != nil {
+		return err
+	}
+	if err := d.checkScope(ctx, id); err != nil {
+		return err
+	}
 	req := request{
 		ctx,
 		http.MethodDelete,
@@ -225,7 +355,7 @@ func (d API[R, W, PK]) Delete(ctx context.Context, id PK) error {
 		nil,
 	}
 
-	err := d.executeRequest(req, http.StatusNoContent, nil)
+	err = d.executeRequest(req, http.StatusNoContent, nil)
 	if err != nil {
 		return fmt.Errorf("execute delete request: %w", err)
 	}
@@ -236,35 +366,286 @@ func (d API[R, W, PK]) Delete(ctx context.Context, id PK) error {
 //
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#update-an-item
-func (d API[R, W, PK]) Items(ctx context.Context, q query) ([]R, error) {
-	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
-	qv := q.asKeyValue(d.Version)
-	qv["fields"] = strings.Join(d.jsonFieldsR(), ",")
+func (d API[R, W, PK]) Items(ctx context.Context, q Query) ([]R, error) {
+	req, err := d.itemsRequest(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if d.Coalescer != nil {
+		items, err := d.Coalescer.do(ctx, coalesceKey(req), d.clock(), func(ctx context.Context) (any, error) {
+			req.ctx = ctx
+			return d.fetchItems(req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append([]R(nil), items.([]R)...), nil
+	}
+	return d.fetchItems(req)
+}
 
-	req := request{
+func (d API[R, W, PK]) fetchItems(req request) ([]R, error) {
+	var respBody itemsEnvelope[R]
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute items request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// ItemsInto retrieves items like Items into *dst reusing its capacity, e.g. of a slice taken from
+// a sync.Pool, so services fetching the same lists repeatedly don't allocate a new slice every time.
+// Previous items of *dst are overwritten, *dst is truncated to zero length when the request fails.
+func (d API[R, W, PK]) ItemsInto(ctx context.Context, q Query, dst *[]R) error {
+	*dst = (*dst)[:0]
+	req, err := d.itemsRequest(ctx, q)
+	if err != nil {
+		return err
+	}
+	if d.Coalescer != nil {
+		items, err := d.Coalescer.do(ctx, coalesceKey(req), d.clock(), func(ctx context.Context) (any, error) {
+			req.ctx = ctx
+			return d.fetchItems(req)
+		})
+		if err != nil {
+			return err
+		}
+		*dst = append(*dst, items.([]R)...)
+		return nil
+	}
+	respBody := itemsEnvelope[R]{Data: *dst}
+	if err := d.executeRequest(req, http.StatusOK, &respBody); err != nil {
+		return fmt.Errorf("execute items request: %w", err)
+	}
+	*dst = respBody.Data
+	return nil
+}
+
+// ItemsMeta are counts of items returned by ItemsWithMeta
+type ItemsMeta struct {
+	// TotalCount is the number of all items of the collection
+	TotalCount int
+	// FilterCount is the number of items matching filters of the query regardless of its pagination
+	FilterCount int
+}
+
+// ItemsWithMeta returns items like Items together with their counts, e.g. for pagination controls
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#metadata
+// https://v8.docs.directus.io/reference/query/meta.html
+func (d API[R, W, PK]) ItemsWithMeta(ctx context.Context, q Query) ([]R, ItemsMeta, error) {
+	req, err := d.itemsRequest(ctx, q)
+	if err != nil {
+		return nil, ItemsMeta{}, err
+	}
+	req.qv["meta"] = "*"
+	if d.Version == V8 {
+		req.qv["meta"] = "total_count,filter_count"
+	}
+	var respBody itemsEnvelope[R]
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, ItemsMeta{}, fmt.Errorf("execute items request: %w", err)
+	}
+	return respBody.Data, respBody.Meta, nil
+}
+
+// itemsRequest returns a request of Items, q is completed by defaults, scoped and validated
+func (d API[R, W, PK]) itemsRequest(ctx context.Context, q Query) (request, error) {
+	q, err := d.scopeQuery(ctx, q.withDefaults(d.DefaultQuery))
+	if err != nil {
+		return request{}, err
+	}
+	if err := q.Validate(); err != nil {
+		return request{}, err
+	}
+	if err := d.supported(q); err != nil {
+		return request{}, err
+	}
+	if d.Schema != nil {
+		if err := d.Schema.validate(q); err != nil {
+			return request{}, err
+		}
+	}
+	u, qv := d.withContextFields(ctx).itemsRequestParams(q)
+	d.localize(ctx, qv)
+
+	return request{
 		ctx,
 		http.MethodGet,
 		u,
 		qv,
 		nil,
+	}, nil
+}
+
+// supported reports filters of q which the server version can't express
+func (d API[R, W, PK]) supported(q Query) error {
+	if d.Version == V8 {
+		return q.validateV8()
 	}
-	var respBody struct {
-		Data []R `json:"data"`
+	return nil
+}
+
+// ItemsURL returns the url requested by Items for the given query,
+// it's meant for debugging of queries which don't return expected items
+func (d API[R, W, PK]) ItemsURL(q Query) string {
+	u, qv := d.itemsRequestParams(q.withDefaults(d.DefaultQuery))
+	return u + "?" + encodeQuery(qv)
+}
+
+func (d API[R, W, PK]) itemsRequestParams(q Query) (string, map[string]string) {
+	u := d.itemsURL()
+	qv := q.asKeyValueAt(d.Version, d.clock().Now())
+	for k, v := range d.fieldParams() {
+		qv[k] = v
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	return u, qv
+}
+
+// itemsURL returns an url of items of the collection, system collections have their own endpoints
+func (d API[R, W, PK]) itemsURL() string {
+	name := strings.TrimPrefix(d.CollectionName, systemPrefix)
+	if name == d.CollectionName {
+		return fmt.Sprintf("%s/items/%s", d.baseURL(), d.CollectionName)
+	}
+	if d.Version == V8 && name == "presets" {
+		name = "collection_presets"
+	}
+	return d.baseURL() + "/" + name
+}
+
+func (d API[R, W, PK]) itemURL(id PK) (string, error) {
+	s, err := formatPK(id)
 	if err != nil {
-		return nil, fmt.Errorf("execute items request: %w", err)
+		return "", err
 	}
-	return respBody.Data, nil
+	return d.itemsURL() + "/" + url.PathEscape(s), nil
 }
 
-func (d *API[R, W, PK]) jsonFieldsR() []string {
-	if d.queryFields == nil {
-		var x R
-		t := reflect.TypeOf(x)
-		d.queryFields = iterateFields(t, "")
+// formatPK renders a primary key for urls and filters. Keys implementing encoding.TextMarshaler
+// or fmt.Stringer render themselves, other keys are formatted by their underlying type.
+// Numeric keys have to render as plain integers, e.g. not as 1e+06 or ID(1).
+func formatPK[PK PrimaryKey](id PK) (string, error) {
+	var s string
+	switch v := any(id).(type) {
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("marshal primary key: %w", err)
+		}
+		s = string(b)
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		rv := reflect.ValueOf(id)
+		switch rv.Kind() {
+		case reflect.String:
+			return rv.String(), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10), nil
+		default:
+			return strconv.FormatUint(rv.Uint(), 10), nil
+		}
 	}
-	return d.queryFields
+	if reflect.ValueOf(id).Kind() != reflect.String && !integerRegexp.MatchString(s) {
+		return "", fmt.Errorf("numeric primary key %v is rendered as %q instead of an integer", reflect.ValueOf(id), s)
+	}
+	return s, nil
+}
+
+var integerRegexp = regexp.MustCompile(`^-?[0-9]+$`)
+
+// baseURL returns an url of the Directus instance including the project namespace
+func (d API[R, W, PK]) baseURL() string {
+	if cfg := d.LiveConfig.Config(); cfg.Host != "" {
+		d.Scheme, d.Host, d.Namespace = cfg.Scheme, cfg.Host, cfg.Namespace
+		if d.Scheme == "" {
+			d.Scheme = "https"
+		}
+	}
+	if d.Namespace == "" {
+		return fmt.Sprintf("%s://%s", d.Scheme, d.Host)
+	}
+	return fmt.Sprintf("%s://%s/%s", d.Scheme, d.Host, d.Namespace)
+}
+
+// readFields caches fields of read models computed by reflection keyed by (*R)(nil)
+var readFields sync.Map
+
+// jsonFieldsR returns fields requested for the read model, the returned slice
+// has no spare capacity, so appending to it never modifies the cached fields
+func (d API[R, W, PK]) jsonFieldsR() []string {
+	fields := d.queryFields
+	if fields == nil {
+		fields = readModelFields[R]()
+	}
+	return fields[:len(fields):len(fields)]
+}
+
+func readModelFields[R any]() []string {
+	if c, ok := lookupCodec[R](); ok && c.Fields != nil {
+		return c.Fields
+	}
+	if f, ok := readFields.Load((*R)(nil)); ok {
+		return f.([]string)
+	}
+	if atomic.LoadInt32(&fieldReflectionForbidden) == 1 {
+		panic(fmt.Sprintf("directusapi: fields of %T are not registered and field reflection is forbidden", *new(R)))
+	}
+	fields := reflectFields[R]()
+	readFields.Store((*R)(nil), fields)
+	return fields
+}
+
+func reflectFields[R any]() []string {
+	var x R
+	t := reflect.TypeOf(x)
+	if t == nil || t.Kind() != reflect.Struct {
+		// dynamic read models like map[string]any get all fields
+		return []string{"*"}
+	}
+	return iterateFields(t, "")
+}
+
+// WithFields returns a copy of the API requesting only the given fields of the read model,
+// e.g. for list views which don't need all of them. Dot separated paths select fields of relations.
+func (d API[R, W, PK]) WithFields(fields ...string) API[R, W, PK] {
+	d.queryFields = append([]string{}, fields...)
+	return d
+}
+
+type fieldsCtxKey struct{}
+
+// WithFields returns a context requesting only the given fields of the read model by Items and GetByID,
+// so hot paths can read a narrow projection without a second read model or a copy of the API.
+// Fields left out keep their zero values. Dot separated paths select fields of relations.
+func WithFields(ctx context.Context, fields ...string) context.Context {
+	return context.WithValue(ctx, fieldsCtxKey{}, append([]string{}, fields...))
+}
+
+// withContextFields returns a copy of the API requesting fields of ctx set by WithFields
+func (d API[R, W, PK]) withContextFields(ctx context.Context) API[R, W, PK] {
+	if fields, ok := ctx.Value(fieldsCtxKey{}).([]string); ok {
+		d.queryFields = fields
+	}
+	return d
+}
+
+// WithToken returns a copy of the API authenticated by a static token,
+// it replaces TokenProvider, Auth, TokenRefresher and the token of LiveConfig of the copy
+func (d API[R, W, PK]) WithToken(token string) API[R, W, PK] {
+	d.BearerToken = token
+	d.fixedToken = true
+	d.TokenProvider, d.Auth, d.TokenRefresher = nil, nil, nil
+	return d
+}
+
+// WithDebug returns a copy of the API which dumps all its requests and responses when enabled
+func (d API[R, W, PK]) WithDebug(enabled bool) API[R, W, PK] {
+	d.debug = enabled
+	return d
 }
 
 // iterateFields returns fields for all struct's fields
@@ -281,10 +662,22 @@ func iterateFields(t reflect.Type, prefix string) []string {
 func structFields(f reflect.StructField, prefix string) []string {
 	tagVal := ""
 	if v, ok := f.Tag.Lookup(tagName); ok {
-		tagVal = v
+		tagVal, _, _ = strings.Cut(v, ",")
 	} else {
 		tagVal = f.Name
 	}
+	if prefix != "" {
+		if _, ok := tagAlias(f); ok {
+			panic(f.Name + "(" + prefix + "): alias is supported only by top level fields of the read model")
+		}
+	}
+	if _, ok := lookupTypeHook(f.Type); ok {
+		// hooked types are decoded as a whole
+		if prefix != "" {
+			return []string{prefix + "." + tagVal}
+		}
+		return []string{tagVal}
+	}
 	switch f.Type.Kind() {
 	case reflect.Struct:
 		var t Time
@@ -316,7 +709,8 @@ func structFields(f reflect.StructField, prefix string) []string {
 		} else {
 			p = tagVal
 		}
-		if f.Type.Elem().Kind() == reflect.Struct {
+		_, elemHooked := lookupTypeHook(f.Type.Elem())
+		if f.Type.Elem().Kind() == reflect.Struct && !elemHooked {
 			return iterateFields(f.Type.Elem(), p)
 		}
 		return []string{p}
@@ -1,9 +1,14 @@
 package directusapi
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJsonFields(t *testing.T) {
@@ -28,3 +33,146 @@ func TestJsonFields(t *testing.T) {
 	jsonFields := api.jsonFieldsR()
 	assert.Equal(t, expected, jsonFields)
 }
+
+func TestAPICopies(t *testing.T) {
+	var reqs []*http.Request
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	api.BearerToken = "service"
+
+	names := api.WithFields("id", "name")
+	user := api.WithToken("user")
+	_, err := names.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = user.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = api.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "id,name", reqs[0].URL.Query().Get("fields"))
+	assert.Equal(t, "Bearer user", reqs[1].Header.Get("Authorization"))
+	assert.Equal(t, strings.Join(api.jsonFieldsR(), ","), reqs[2].URL.Query().Get("fields"))
+	assert.Equal(t, "Bearer service", reqs[2].Header.Get("Authorization"))
+	assert.True(t, api.WithDebug(true).debug)
+	assert.False(t, api.debug)
+
+	// appending to the fields of one request doesn't change fields of others
+	fields := append(api.jsonFieldsR(), "date_updated")
+	assert.NotContains(t, api.jsonFieldsR(), "date_updated")
+	assert.Contains(t, fields, "date_updated")
+}
+
+func TestWithFieldsContext(t *testing.T) {
+	var fields []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		if strings.HasSuffix(r.URL.Path, "/1") {
+			_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"}]}`))
+	}))
+
+	ctx := WithFields(context.Background(), "id", "name")
+	fruit, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "kiwi", fruit.Name)
+	_, err = api.Items(ctx, None())
+	require.NoError(t, err)
+	_, err = api.Items(context.Background(), None())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"id,name", "id,name", strings.Join(api.jsonFieldsR(), ",")}, fields)
+}
+
+func TestItemsWithMeta(t *testing.T) {
+	var meta string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		meta = r.URL.Query().Get("meta")
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"}],"meta":{"total_count":12,"filter_count":"3"}}`))
+	}))
+
+	fruits, counts, err := api.ItemsWithMeta(context.Background(), Eq("name", "kiwi").Limit(1))
+	require.NoError(t, err)
+	assert.Equal(t, "total_count,filter_count", meta)
+	assert.Len(t, fruits, 1)
+	assert.Equal(t, ItemsMeta{TotalCount: 12, FilterCount: 3}, counts)
+
+	api.Version = V9
+	_, _, err = api.ItemsWithMeta(context.Background(), None())
+	require.NoError(t, err)
+	assert.Equal(t, "*", meta)
+}
+
+type stringerID int
+
+func (id stringerID) String() string {
+	return fmt.Sprintf("ID(%d)", int(id))
+}
+
+type textID int64
+
+func (id textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d", int64(id)*10)), nil
+}
+
+type slug string
+
+func (s slug) String() string {
+	return "fruit/" + string(s)
+}
+
+func TestItemsInto(t *testing.T) {
+	body := `{"data":[{"id":1,"name":"kiwi"},{"id":2}]}`
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+
+	dst := make([]FruitR, 0, 4)
+	dst = append(dst, FruitR{ID: 7, Name: "fig", Status: "published"})
+	backing := &dst[:1][0]
+	require.NoError(t, api.ItemsInto(context.Background(), None(), &dst))
+	assert.Equal(t, []FruitR{{ID: 1, Name: "kiwi"}, {ID: 2}}, dst)
+	assert.Same(t, backing, &dst[0], "capacity should be reused")
+
+	// a bigger response gets a new slice
+	body = `{"data":[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}]}`
+	require.NoError(t, api.ItemsInto(context.Background(), None(), &dst))
+	assert.Len(t, dst, 5)
+
+	var empty []FruitR
+	body = `{"data":[]}`
+	require.NoError(t, api.ItemsInto(context.Background(), None(), &empty))
+	assert.Empty(t, empty)
+
+	body = `{"data":`
+	assert.Error(t, api.ItemsInto(context.Background(), None(), &dst))
+	assert.Empty(t, dst)
+}
+
+func TestFormatPK(t *testing.T) {
+	for _, tc := range []struct {
+		format func() (string, error)
+		want   string
+	}{
+		{func() (string, error) { return formatPK(42) }, "42"},
+		{func() (string, error) { return formatPK(uint64(1 << 63)) }, "9223372036854775808"},
+		{func() (string, error) { return formatPK("kiwi") }, "kiwi"},
+		{func() (string, error) { return formatPK(textID(7)) }, "70"},
+		{func() (string, error) { return formatPK(slug("kiwi")) }, "fruit/kiwi"},
+	} {
+		got, err := tc.format()
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+
+	_, err := formatPK(stringerID(1))
+	assert.ErrorContains(t, err, `rendered as "ID(1)"`)
+
+	api := API[FruitR, FruitW, slug]{Scheme: "http", Host: "localhost", CollectionName: "fruits"}
+	u, err := api.itemURL("kiwi")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/items/fruits/fruit%2Fkiwi", u)
+}
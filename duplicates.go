@@ -0,0 +1,59 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FindDuplicates retrieves items which equal item in all fields named by keys,
+// keys are JSON names of the write model's fields. It's meant for import pipelines
+// deciding whether to skip or merge an item before inserting it.
+func (d API[R, W, PK]) FindDuplicates(ctx context.Context, item W, keys ...string) ([]R, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+	body, err := marshalBody(item)
+	if err != nil {
+		return nil, fmt.Errorf("marshal item: %w", err)
+	}
+	raw, ok := body.(json.RawMessage)
+	if !ok {
+		if raw, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("marshal item: %w", err)
+		}
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("item is not an object: %w", err)
+	}
+
+	q := None()
+	for _, k := range keys {
+		v, ok := fields[k]
+		if !ok {
+			return nil, fmt.Errorf("item has no field %q", k)
+		}
+		var val any
+		if err := json.Unmarshal(v, &val); err != nil {
+			return nil, fmt.Errorf("decode field %q: %w", k, err)
+		}
+		switch val := val.(type) {
+		case nil:
+			q = q.Null(k)
+		case string:
+			q = q.Eq(k, val)
+		case float64, bool:
+			// keep the JSON representation, so big numbers aren't rounded
+			q = q.Eq(k, string(v))
+		default:
+			return nil, fmt.Errorf("field %q of type %T can't be compared", k, val)
+		}
+	}
+
+	items, err := d.Items(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("find duplicates: %w", err)
+	}
+	return items, nil
+}
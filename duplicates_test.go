@@ -0,0 +1,36 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		assert.Equal(t, "kiwi", qv.Get("filter[name][eq]"))
+		assert.Equal(t, "20", qv.Get("filter[weight][eq]"))
+		assert.Equal(t, "true", qv.Get("filter[enabled][eq]"))
+		assert.True(t, qv.Has("filter[price][null]"))
+		_, _ = w.Write([]byte(`{"data":[{"id":3,"name":"kiwi"}]}`))
+	}))
+
+	dups, err := api.FindDuplicates(context.Background(), FruitW{
+		Name:    "kiwi",
+		Weight:  20,
+		Enabled: true,
+		Price:   UnsetOptional[float64](),
+	}, "name", "weight", "enabled", "price")
+	require.NoError(t, err)
+	require.Len(t, dups, 1)
+	assert.Equal(t, 3, dups[0].ID)
+
+	_, err = api.FindDuplicates(context.Background(), FruitW{Area: []string{"europe"}}, "area")
+	assert.Error(t, err)
+	_, err = api.FindDuplicates(context.Background(), FruitW{}, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Endpoint calls a custom endpoint extension with a typed request and response
+type Endpoint[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// RegisterEndpoint returns a typed binding of a custom endpoint extension at path, e.g. /recommendations/similar,
+// which uses auth, retries and error handling of api. The request is sent as a JSON body,
+// fields of its JSON object are sent as query parameters of GET requests. A request implementing io.Reader
+// is sent as it is, it's buffered for retries unless it implements io.Seeker. The whole response body
+// is decoded to Resp, the endpoint has to respond with 200 OK.
+//
+// Related Directus reference:
+// https://docs.directus.io/extensions/endpoints.html
+func RegisterEndpoint[Req, Resp, R, W any, PK PrimaryKey](api API[R, W, PK], method, path string) Endpoint[Req, Resp] {
+	u := api.baseURL() + "/" + strings.TrimPrefix(path, "/")
+	return func(ctx context.Context, in Req) (Resp, error) {
+		var out Resp
+		var body any = in
+		var qv map[string]string
+		if method == http.MethodGet {
+			var err error
+			if qv, err = queryParams(in); err != nil {
+				return out, err
+			}
+			body = nil
+		}
+		req := request{
+			ctx,
+			method,
+			u,
+			qv,
+			body,
+		}
+		if err := api.executeRequest(req, http.StatusOK, &out); err != nil {
+			return out, fmt.Errorf("execute %s request: %w", path, err)
+		}
+		return out, nil
+	}
+}
+
+// queryParams converts fields of a JSON object to query parameters, nested values are sent as JSON
+func queryParams(v any) (map[string]string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal query: %w", err)
+	}
+	if string(b) == "null" {
+		return nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("query is not an object: %w", err)
+	}
+	out := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			out[k] = s
+		} else {
+			out[k] = string(raw)
+		}
+	}
+	return out, nil
+}
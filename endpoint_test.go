@@ -0,0 +1,58 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type similarQuery struct {
+	ID    int `json:"id"`
+	Limit int `json:"limit"`
+}
+
+type similarFruits struct {
+	IDs []int `json:"ids"`
+}
+
+func TestRegisterEndpoint(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/_/recommendations/similar", r.URL.Path)
+			assert.Equal(t, "1", r.URL.Query().Get("id"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"ids":[3,4]}`))
+		case http.MethodPost:
+			var q similarQuery
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&q))
+			if q.ID == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":[{"message":"id is required","extensions":{"code":"INVALID_PAYLOAD"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ids":[5]}`))
+		}
+	}))
+	api.BearerToken = "token"
+
+	similar := RegisterEndpoint[similarQuery, similarFruits](api, http.MethodGet, "/recommendations/similar")
+	resp, err := similar(context.Background(), similarQuery{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, resp.IDs)
+
+	compute := RegisterEndpoint[similarQuery, similarFruits](api, http.MethodPost, "recommendations/compute")
+	resp, err = compute(context.Background(), similarQuery{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int{5}, resp.IDs)
+
+	_, err = compute(context.Background(), similarQuery{})
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.True(t, respErr.HasCode("INVALID_PAYLOAD"))
+}
@@ -0,0 +1,161 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ResponseError is returned when Directus responds with an unexpected status
+// or, with API.StrictResponses, with errors in a response of the expected status
+type ResponseError struct {
+	StatusCode int
+	Status     string
+	// Errors are parsed from the body, it's empty when the body isn't in the Directus errors format
+	Errors []ErrorDetail
+	Body   []byte
+}
+
+// ErrorDetail is a single error reported by Directus.
+// Code is e.g. FORBIDDEN for v9 and a numeric code like 203 for v8.
+type ErrorDetail struct {
+	Code    string
+	Message string
+}
+
+func (e *ResponseError) Error() string {
+	if e.StatusCode >= 200 && e.StatusCode < 300 {
+		return fmt.Sprintf("errors in response of status %s: %s", e.Status, string(e.Body))
+	}
+	return fmt.Sprintf("unexpected status %s: %s", e.Status, string(e.Body))
+}
+
+// HasCode reports whether Directus reported an error with the given code
+func (e *ResponseError) HasCode(code string) bool {
+	for _, d := range e.Errors {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func newResponseError(statusCode int, status string, body []byte) *ResponseError {
+	return &ResponseError{
+		StatusCode: statusCode,
+		Status:     status,
+		Errors:     parseErrorDetails(body),
+		Body:       body,
+	}
+}
+
+// parseErrorDetails parses errors of both v9 and v8 response bodies
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/introduction.html#error-codes
+// https://v8.docs.directus.io/api/errors.html
+func parseErrorDetails(body []byte) []ErrorDetail {
+	var parsed struct {
+		// v9
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+		// v8
+		Error *struct {
+			Code    json.Number `json:"code"`
+			Message string      `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil
+	}
+	var details []ErrorDetail
+	for _, e := range parsed.Errors {
+		details = append(details, ErrorDetail{e.Extensions.Code, e.Message})
+	}
+	if parsed.Error != nil {
+		details = append(details, ErrorDetail{parsed.Error.Code.String(), parsed.Error.Message})
+	}
+	return details
+}
+
+// PayloadSizeError is reported when a request body exceeds API.MaxPayloadSize
+type PayloadSizeError struct {
+	Size  int64
+	Limit int64
+}
+
+func (e *PayloadSizeError) Error() string {
+	return fmt.Sprintf("request body of %d bytes exceeds the server limit of %d bytes, split it into smaller requests", e.Size, e.Limit)
+}
+
+// OperationError is returned by every failed request, it carries metadata for grouping of failures
+// in logs and wraps the cause, e.g. *ResponseError. Its message is the message of the cause.
+type OperationError struct {
+	Collection string
+	// Operation is get, list, create, update or delete for items, the endpoint path otherwise
+	Operation string
+	// ID is set for operations on a single item
+	ID     string
+	Method string
+	// URL has secrets in the query redacted
+	URL      string
+	Attempts int
+	Duration time.Duration
+	Err      error
+}
+
+func (e *OperationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+func (a *API[R, W, PK]) operationError(r request, attempts int, duration time.Duration, err error) error {
+	opErr := &OperationError{
+		Collection: a.CollectionName,
+		Method:     r.method,
+		URL:        r.url,
+		Attempts:   attempts,
+		Duration:   duration,
+		Err:        err,
+	}
+	if u, perr := url.Parse(r.url); perr == nil {
+		qv := u.Query()
+		for k, v := range r.qv {
+			qv.Set(k, v)
+		}
+		u.RawQuery = qv.Encode()
+		opErr.URL = redactURL(u)
+	}
+
+	itemsURL := a.itemsURL()
+	switch {
+	case strings.HasPrefix(r.url, itemsURL+"/"):
+		opErr.ID = strings.TrimPrefix(r.url, itemsURL+"/")
+		opErr.Operation = map[string]string{
+			http.MethodGet:    "get",
+			http.MethodPatch:  "update",
+			http.MethodDelete: "delete",
+		}[r.method]
+	case r.url == itemsURL:
+		opErr.Operation = map[string]string{
+			http.MethodGet:    "list",
+			http.MethodPost:   "create",
+			http.MethodPatch:  "update",
+			http.MethodDelete: "delete",
+		}[r.method]
+	}
+	if opErr.Operation == "" {
+		opErr.Operation = strings.TrimPrefix(r.url, a.baseURL())
+	}
+	return opErr
+}
@@ -0,0 +1,85 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationError(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"maintenance","extensions":{"code":"SERVICE_UNAVAILABLE"}}]}`))
+	}))
+	api.MaxRetries = 2
+	api.RetryBackoff = time.Millisecond
+
+	_, err := api.GetByID(context.Background(), 7)
+	var opErr *OperationError
+	require.True(t, errors.As(err, &opErr), err)
+	assert.Equal(t, "fruits", opErr.Collection)
+	assert.Equal(t, "get", opErr.Operation)
+	assert.Equal(t, "7", opErr.ID)
+	assert.Equal(t, http.MethodGet, opErr.Method)
+	assert.Equal(t, 3, opErr.Attempts)
+	assert.Positive(t, opErr.Duration)
+	var respErr *ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.True(t, respErr.HasCode("SERVICE_UNAVAILABLE"))
+
+	_, err = api.Items(context.Background(), None())
+	require.True(t, errors.As(err, &opErr))
+	assert.Equal(t, "list", opErr.Operation)
+	assert.Empty(t, opErr.ID)
+
+	_, err = api.ServerVersion(context.Background())
+	require.True(t, errors.As(err, &opErr))
+	assert.Equal(t, "/server/info", opErr.Operation)
+}
+
+func TestOperationErrorRedactsURL(t *testing.T) {
+	api := API[FruitR, FruitW, int]{Scheme: "http", Host: "localhost", CollectionName: "fruits"}
+	err := api.operationError(request{
+		context.Background(),
+		http.MethodGet,
+		"http://localhost/items/fruits",
+		map[string]string{"access_token": "s3cr3t", "limit": "1"},
+		nil,
+	}, 1, 0, errors.New("boom"))
+	var opErr *OperationError
+	require.True(t, errors.As(err, &opErr))
+	assert.Equal(t, "http://localhost/items/fruits?access_token=REDACTED&limit=1", opErr.URL)
+	assert.Equal(t, "boom", err.Error())
+}
+
+func TestStrictResponses(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"You don't have permission to access this.","extensions":{"code":"FORBIDDEN"}}]}`))
+	}))
+
+	// without strict mode the missing data is decoded as a zero value
+	fruit, err := api.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Zero(t, fruit)
+
+	api.StrictResponses = true
+	_, err = api.GetByID(context.Background(), 1)
+	var respErr *ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusOK, respErr.StatusCode)
+	assert.True(t, respErr.HasCode("FORBIDDEN"))
+	assert.Contains(t, err.Error(), "errors in response of status 200 OK")
+
+	ok := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	ok.StrictResponses = true
+	fruit, err = ok.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "kiwi", fruit.Name)
+}
@@ -0,0 +1,49 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ExportFormat is a file format of Export
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+	ExportXML  ExportFormat = "xml"
+)
+
+// streamDest is a destination of executeRequest copying the response body to w without decoding it
+type streamDest struct {
+	w io.Writer
+}
+
+// Export streams items matching q rendered by the server in the given format to w,
+// so large dumps aren't decoded into the read model. Fields of the read model are exported,
+// use WithFields("*") for all of them. It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#export
+func (d API[R, W, PK]) Export(ctx context.Context, w io.Writer, format ExportFormat, q Query) error {
+	if d.Version == V8 {
+		return errors.New("export is supported only by v9")
+	}
+	switch format {
+	case ExportCSV, ExportJSON, ExportXML:
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+	req, err := d.itemsRequest(ctx, q)
+	if err != nil {
+		return err
+	}
+	req.qv["export"] = string(format)
+	if err := d.executeRequest(req, http.StatusOK, &streamDest{w}); err != nil {
+		return fmt.Errorf("execute export request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "csv", r.URL.Query().Get("export"))
+		assert.Equal(t, "kiwi", r.URL.Query().Get("filter[name][_eq]"))
+		if r.URL.Query().Get("limit") == "1" {
+			// the connection is closed before the whole body is sent
+			w.Header().Set("Content-Length", "100")
+			_, _ = w.Write([]byte("id,name\n"))
+			return
+		}
+		_, _ = w.Write([]byte("id,name\n1,kiwi\n"))
+	}))
+	api.Version = V9
+	api.MaxRetries = 2
+
+	var buf bytes.Buffer
+	require.NoError(t, api.Export(context.Background(), &buf, ExportCSV, Eq("name", "kiwi")))
+	assert.Equal(t, "id,name\n1,kiwi\n", buf.String())
+
+	buf.Reset()
+	require.Error(t, api.Export(context.Background(), &buf, ExportCSV, Eq("name", "kiwi").Limit(1)))
+	assert.Equal(t, "id,name\n", buf.String(), "a partially written stream should not be retried")
+	assert.EqualValues(t, 2, calls)
+
+	assert.Error(t, api.Export(context.Background(), &buf, "yaml", None()))
+	api.Version = V8
+	assert.Error(t, api.Export(context.Background(), &buf, ExportCSV, None()))
+}
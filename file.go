@@ -0,0 +1,91 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// File is a read model of directus_files, use it for fields related to files
+// so they are expanded into usable data. ID is a uuid for v9 and a number for v8,
+// Width and Height are zero for files which are not images.
+type File struct {
+	ID               string `json:"id"`
+	FilenameDownload string `json:"filename_download"`
+	Type             string `json:"type"`
+	Filesize         int64  `json:"filesize"`
+	Width            int    `json:"width"`
+	Height           int    `json:"height"`
+}
+
+func (f *File) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	// relation which isn't expanded holds only the id
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		id, err := rawString(data)
+		if err != nil {
+			return fmt.Errorf("file id: %w", err)
+		}
+		*f = File{ID: id}
+		return nil
+	}
+
+	var raw struct {
+		ID               json.RawMessage `json:"id"`
+		FilenameDownload *string         `json:"filename_download"`
+		Type             *string         `json:"type"`
+		Filesize         json.RawMessage `json:"filesize"`
+		Width            *int            `json:"width"`
+		Height           *int            `json:"height"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	id, err := rawString(raw.ID)
+	if err != nil {
+		return fmt.Errorf("file id: %w", err)
+	}
+	// bigInteger filesize is returned as a string by newer versions
+	size, err := rawString(raw.Filesize)
+	if err != nil {
+		return fmt.Errorf("file size: %w", err)
+	}
+	*f = File{ID: id}
+	if size != "" {
+		if f.Filesize, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return fmt.Errorf("file size: %w", err)
+		}
+	}
+	if raw.FilenameDownload != nil {
+		f.FilenameDownload = *raw.FilenameDownload
+	}
+	if raw.Type != nil {
+		f.Type = *raw.Type
+	}
+	if raw.Width != nil {
+		f.Width = *raw.Width
+	}
+	if raw.Height != nil {
+		f.Height = *raw.Height
+	}
+	return nil
+}
+
+// rawString returns a JSON string or number as a string, null is empty
+func rawString(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
@@ -0,0 +1,49 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type article struct {
+	Title string         `json:"title"`
+	Cover File           `json:"cover"`
+	Logo  Optional[File] `json:"logo"`
+}
+
+func TestFileFields(t *testing.T) {
+	api := API[article, article, int]{}
+	assert.Equal(t, []string{
+		"title",
+		"cover.id", "cover.filename_download", "cover.type", "cover.filesize", "cover.width", "cover.height",
+		"logo.id", "logo.filename_download", "logo.type", "logo.filesize", "logo.width", "logo.height",
+	}, api.jsonFieldsR())
+}
+
+func TestFileUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want File
+	}{
+		"v9": {
+			`{"id":"8a2c","filename_download":"a.png","type":"image/png","filesize":"1024","width":64,"height":32}`,
+			File{"8a2c", "a.png", "image/png", 1024, 64, 32},
+		},
+		"v8": {
+			`{"id":3,"filename_download":"a.pdf","type":"application/pdf","filesize":2048,"width":null,"height":null}`,
+			File{ID: "3", FilenameDownload: "a.pdf", Type: "application/pdf", Filesize: 2048},
+		},
+		"id only": {`"8a2c"`, File{ID: "8a2c"}},
+		"null":    {`null`, File{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var f File
+			require.NoError(t, json.Unmarshal([]byte(tt.in), &f))
+			assert.Equal(t, tt.want, f)
+		})
+	}
+}
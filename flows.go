@@ -0,0 +1,202 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// FlowData is a data chain of a flow. It's what the "Webhook / Request URL"
+// operation sends when its body is set to {{$data}}, T is a shape of the trigger.
+//
+// Related Directus reference:
+// https://docs.directus.io/app/flows.html#data-chain
+type FlowData[T any] struct {
+	Trigger        T                  `json:"$trigger"`
+	Accountability FlowAccountability `json:"$accountability"`
+	Last           json.RawMessage    `json:"$last"`
+	Env            map[string]any     `json:"$env"`
+}
+
+// FlowAccountability describes who triggered the flow
+type FlowAccountability struct {
+	User      string `json:"user"`
+	Role      string `json:"role"`
+	Admin     bool   `json:"admin"`
+	App       bool   `json:"app"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+	Origin    string `json:"origin"`
+}
+
+// FlowEventTrigger is a trigger of flows started by an event hook.
+// P is a payload of the event, Key is set for create events
+// while Keys are set for update and delete events.
+//
+// Related Directus reference:
+// https://docs.directus.io/app/flows/triggers.html#event-hook
+type FlowEventTrigger[P any, PK PrimaryKey] struct {
+	Event      string       `json:"event"`
+	Collection string       `json:"collection"`
+	Payload    P            `json:"payload"`
+	Key        Optional[PK] `json:"key"`
+	Keys       []PK         `json:"keys"`
+}
+
+// FlowWebhookTrigger is a trigger of flows started by an incoming request, B is a request body
+//
+// Related Directus reference:
+// https://docs.directus.io/app/flows/triggers.html#webhook
+type FlowWebhookTrigger[B any] struct {
+	Path    string            `json:"path"`
+	Query   map[string]any    `json:"query"`
+	Body    B                 `json:"body"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+}
+
+// FlowManualBody is a body of the manual trigger
+type FlowManualBody[PK PrimaryKey] struct {
+	Collection string `json:"collection"`
+	Keys       []PK   `json:"keys"`
+}
+
+// FlowManualTrigger is a trigger of flows started manually from the app
+//
+// Related Directus reference:
+// https://docs.directus.io/app/flows/triggers.html#manual
+type FlowManualTrigger[PK PrimaryKey] FlowWebhookTrigger[FlowManualBody[PK]]
+
+// FlowError is an error which can be returned from a FlowHandler function
+// to control the response status, the error is rendered in Directus errors format
+type FlowError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e FlowError) Error() string {
+	return fmt.Sprintf("flow error %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+type flowErrorsBody struct {
+	Errors []flowErrorBody `json:"errors"`
+}
+
+type flowErrorBody struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code,omitempty"`
+	} `json:"extensions"`
+}
+
+// FlowHandler adapts fn to http.Handler callable by the "Webhook / Request URL" operation.
+// Request body is decoded to Req and fn's response is encoded as JSON, so it becomes
+// $last in the data chain. Errors are responded with status 500 and a generic message unless FlowError
+// is returned, so internal details don't leak to flow logs, fn should log its errors itself.
+//
+// Related Directus reference:
+// https://docs.directus.io/app/flows/operations.html#webhook-request-url
+func FlowHandler[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Req
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFlowError(w, FlowError{
+				Status:  http.StatusBadRequest,
+				Code:    "INVALID_PAYLOAD",
+				Message: fmt.Sprintf("decode request body: %v", err),
+			})
+			return
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			var fe FlowError
+			if !errors.As(err, &fe) {
+				fe = FlowError{
+					Status:  http.StatusInternalServerError,
+					Code:    "INTERNAL_SERVER_ERROR",
+					Message: "internal server error",
+				}
+			}
+			writeFlowError(w, fe)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func writeFlowError(w http.ResponseWriter, fe FlowError) {
+	body := flowErrorBody{Message: fe.Message}
+	body.Extensions.Code = fe.Code
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(fe.Status)
+	_ = json.NewEncoder(w).Encode(flowErrorsBody{[]flowErrorBody{body}})
+}
+
+// FlowSignature signs requests of TriggerFlow with a shared secret,
+// so the flow can reject requests which don't come from the client
+type FlowSignature struct {
+	Secret string
+	// Header carries the signature, defaults to X-Signature
+	Header string
+	// Plain sends the secret itself instead of a hex encoded HMAC-SHA256 of the body
+	Plain bool
+}
+
+func (s *FlowSignature) header() string {
+	if s.Header == "" {
+		return "X-Signature"
+	}
+	return s.Header
+}
+
+func (s *FlowSignature) sign(body []byte) string {
+	if s.Plain {
+		return s.Secret
+	}
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TriggerFlow calls a flow with the webhook trigger by POST and returns its response,
+// the request is signed when FlowSignature is set
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/flows.html#flow-with-get-webhook-trigger
+func (d API[R, W, PK]) TriggerFlow(ctx context.Context, flowID string, body any) (json.RawMessage, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal flow body: %w", err)
+	}
+	if d.FlowSignature != nil {
+		ctx = withHeaders(ctx, map[string]string{
+			d.FlowSignature.header(): d.FlowSignature.sign(raw),
+		})
+	}
+
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/flows/trigger/" + url.PathEscape(flowID),
+		nil,
+		json.RawMessage(raw),
+	}
+	// flows without a response body return nothing
+	var respBody bytes.Buffer
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute trigger flow request: %w", err)
+	}
+	return respBody.Bytes(), nil
+}
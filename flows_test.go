@@ -0,0 +1,104 @@
+package directusapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowHandler(t *testing.T) {
+	type resp struct {
+		Name string `json:"name"`
+	}
+	h := FlowHandler(func(ctx context.Context, req FlowData[FlowEventTrigger[FruitW, int]]) (resp, error) {
+		if req.Trigger.Payload.Name == "" {
+			return resp{}, FlowError{http.StatusUnprocessableEntity, "NAME_REQUIRED", "name is required"}
+		}
+		if req.Trigger.Payload.Name == "fail" {
+			return resp{}, errors.New("dial tcp 10.0.0.7:5432: connection refused")
+		}
+		assert.Equal(t, "fruits.items.create", req.Trigger.Event)
+		assert.Equal(t, 7, req.Trigger.Key.ValueMust())
+		assert.Equal(t, "admin-id", req.Accountability.User)
+		return resp{strings.ToUpper(req.Trigger.Payload.Name)}, nil
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		body := `{
+			"$trigger": {"event": "fruits.items.create", "collection": "fruits", "key": 7, "payload": {"name": "kiwi"}},
+			"$accountability": {"user": "admin-id", "admin": true},
+			"$last": null
+		}`
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"name":"KIWI"}`, rec.Body.String())
+	})
+
+	t.Run("flow error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"$trigger": {}}`)))
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"name is required","extensions":{"code":"NAME_REQUIRED"}}]}`, rec.Body.String())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"$trigger": {"payload": {"name": "fail"}}}`)))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"internal server error","extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`, rec.Body.String())
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestTriggerFlow(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/flows/trigger/f1", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Signature"))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	api.FlowSignature = &FlowSignature{Secret: "s3cr3t"}
+
+	resp, err := api.TriggerFlow(context.Background(), "f1", map[string]int{"id": 1})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(resp))
+}
+
+func TestTriggerFlowEscapesID(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/flows/trigger/a%2Fb%3Fc", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+	}))
+
+	_, err := api.TriggerFlow(context.Background(), "a/b?c", nil)
+	require.NoError(t, err)
+}
+
+func TestTriggerFlowPlainSecret(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "s3cr3t", r.Header.Get("X-Flow-Secret"))
+	}))
+	api.FlowSignature = &FlowSignature{Secret: "s3cr3t", Header: "X-Flow-Secret", Plain: true}
+
+	resp, err := api.TriggerFlow(context.Background(), "f1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, resp)
+}
@@ -0,0 +1,60 @@
+package directusapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Field functions of Directus v9 usable in filters, sort and selected fields,
+// e.g. Eq(Year("date_created"), "2023") or SortDesc(Count("comments")).
+// Functions of relational fields apply to the last field of the path,
+// e.g. Year("author.date_created") is author.year(date_created).
+// Directus v8 doesn't support them.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#functions
+func Year(field string) string    { return fieldFunction("year", field) }
+func Month(field string) string   { return fieldFunction("month", field) }
+func Week(field string) string    { return fieldFunction("week", field) }
+func Day(field string) string     { return fieldFunction("day", field) }
+func Weekday(field string) string { return fieldFunction("weekday", field) }
+func Hour(field string) string    { return fieldFunction("hour", field) }
+func Minute(field string) string  { return fieldFunction("minute", field) }
+func Second(field string) string  { return fieldFunction("second", field) }
+func Count(field string) string   { return fieldFunction("count", field) }
+
+func fieldFunction(fn, field string) string {
+	i := strings.LastIndex(field, ".")
+	return field[:i+1] + fn + "(" + field[i+1:] + ")"
+}
+
+var fieldFunctionRegexp = regexp.MustCompile(`^(\w+)\(([^()]+)\)$`)
+
+// splitFieldFunction returns the function and the field of a path like year(date_created)
+func splitFieldFunction(field string) (fn, inner string, ok bool) {
+	m := fieldFunctionRegexp.FindStringSubmatch(field)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// splitPath splits a dot separated path, dots inside of function arguments don't separate fields
+func splitPath(path string) []string {
+	var out []string
+	depth, start := 0, 0
+	for i, c := range path {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '.':
+			if depth == 0 {
+				out = append(out, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, path[start:])
+}
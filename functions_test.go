@@ -0,0 +1,34 @@
+package directusapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFunctions(t *testing.T) {
+	assert.Equal(t, "year(date_created)", Year("date_created"))
+	assert.Equal(t, "author.month(date_created)", Month("author.date_created"))
+
+	q := Eq(Year("date_created"), "2023").Gte(Count("comments"), "3").Eq(Weekday("author.birthday"), "1").
+		SortDesc(Count("comments"))
+	assert.Equal(t, map[string]string{
+		"limit":                                  "-1",
+		"filter[year(date_created)][_eq]":        "2023",
+		"filter[count(comments)][_gte]":          "3",
+		"filter[author][weekday(birthday)][_eq]": "1",
+		"sort":                                   "-count(comments)",
+	}, q.asKeyValue(V9))
+
+	filter, err := Eq(Year("author.date_created"), "2023").FilterJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"author":{"year(date_created)":{"_eq":"2023"}}}`, string(filter))
+
+	schema := &CollectionSchema{Fields: map[string]FieldSchema{
+		"date_created": {"date_created", "timestamp"},
+	}}
+	assert.NoError(t, schema.validate(Eq(Year("date_created"), "2023")))
+	assert.Error(t, schema.validate(Eq(Year("date_created"), "last year")))
+	assert.Error(t, schema.validate(Eq(Year("date_updated"), "2023")))
+}
@@ -0,0 +1,97 @@
+package directusapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Operation is a kind of request allowed by Guard, operations can be combined, e.g. OpRead|OpUpdate
+type Operation uint8
+
+const (
+	OpRead Operation = 1 << iota
+	OpCreate
+	OpUpdate
+	OpDelete
+
+	OpWrite = OpCreate | OpUpdate | OpDelete
+	OpAll   = OpRead | OpWrite
+)
+
+func (o Operation) String() string {
+	names := []string{}
+	for i, name := range []string{"read", "create", "update", "delete"} {
+		if o&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Guard restricts an API to allowed operations of collections, e.g. read only access to directus_users,
+// so a buggy or compromised component can't change collections it shouldn't touch.
+// Items are checked by their collection, other endpoints as the system collection of their first path
+// segment, e.g. /users as directus_users and /graphql as directus_graphql. Authentication and server
+// endpoints are not guarded. The operation is derived from the HTTP method, GET is a read,
+// POST a create, PATCH and PUT an update and DELETE a delete.
+type Guard struct {
+	// Allow maps collection names to allowed operations, requests of other collections fail
+	Allow map[string]Operation
+}
+
+// GuardError is returned for requests which are not allowed by Guard, they are not sent
+type GuardError struct {
+	Collection string
+	Operation  Operation
+}
+
+func (e *GuardError) Error() string {
+	return fmt.Sprintf("directusapi: %s of %s is not allowed", e.Operation, e.Collection)
+}
+
+// check returns *GuardError when the request isn't allowed, nil guards allow everything
+func (g *Guard) check(method, baseURL, u string) error {
+	if g == nil || !strings.HasPrefix(u, baseURL+"/") {
+		return nil
+	}
+	collection, ok := guardedCollection(strings.TrimPrefix(u, baseURL+"/"))
+	if !ok {
+		return nil
+	}
+	op := methodOperation(method)
+	if g.Allow[collection]&op != op {
+		return &GuardError{collection, op}
+	}
+	return nil
+}
+
+// guardedCollection returns the collection of an endpoint path relative to the base url
+func guardedCollection(path string) (string, bool) {
+	segments := strings.Split(path, "/")
+	switch segments[0] {
+	case "auth", "server":
+		return "", false
+	case "items":
+		if len(segments) > 1 {
+			return segments[1], true
+		}
+	case "collection_presets":
+		return CollectionPresets, true
+	}
+	return systemPrefix + segments[0], true
+}
+
+func methodOperation(method string) Operation {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return OpRead
+	case http.MethodPost:
+		return OpCreate
+	case http.MethodPatch, http.MethodPut:
+		return OpUpdate
+	case http.MethodDelete:
+		return OpDelete
+	}
+	return OpAll
+}
@@ -0,0 +1,51 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	var paths []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	api.Guard = &Guard{Allow: map[string]Operation{
+		"fruits":        OpRead | OpUpdate,
+		CollectionUsers: OpRead,
+	}}
+	ctx := context.Background()
+
+	_, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = api.Update(ctx, 1, map[string]any{"name": "kiwi"})
+	require.NoError(t, err)
+
+	err = api.Delete(ctx, 1)
+	var guardErr *GuardError
+	require.True(t, errors.As(err, &guardErr), err)
+	assert.Equal(t, &GuardError{"fruits", OpDelete}, guardErr)
+	assert.EqualError(t, guardErr, "directusapi: delete of fruits is not allowed")
+
+	users := UsersAPI{Scheme: api.Scheme, Host: api.Host, Namespace: "_", HTTPClient: api.HTTPClient, Guard: api.Guard, CollectionName: CollectionUsers}
+	_, err = users.GetByID(ctx, "1")
+	require.NoError(t, err)
+	_, err = users.Create(ctx, map[string]any{"email": "kiwi@example.com"})
+	require.True(t, errors.As(err, &guardErr), err)
+	assert.Equal(t, &GuardError{CollectionUsers, OpCreate}, guardErr)
+
+	// other collections are not allowed at all
+	roles := api
+	roles.CollectionName = CollectionRoles
+	_, err = roles.Items(ctx, None())
+	require.True(t, errors.As(err, &guardErr), err)
+
+	assert.Equal(t, []string{"GET /_/items/fruits/1", "PATCH /_/items/fruits/1", "GET /_/users/1"}, paths)
+	assert.Equal(t, "read|update", (OpRead | OpUpdate).String())
+}
@@ -0,0 +1,69 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const maxReadyBackoff = 2 * time.Second
+
+// AwaitReady polls the health of the server until it's healthy or timeout elapses,
+// it's meant for integration tests and ordering of container startup.
+// Polls are delayed by exponential backoff starting at RetryBackoff.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/server.html#health
+// https://v8.docs.directus.io/api/server.html#ping-the-server
+func (d API[R, W, PK]) AwaitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	delay := orDefault(d.RetryBackoff, defaultRetryBackoff)
+	for {
+		healthErr := d.health(ctx)
+		if healthErr == nil {
+			return nil
+		}
+		if err := d.sleep(ctx, delay); err != nil {
+			return fmt.Errorf("server is not ready (%v): %w", healthErr, err)
+		}
+		if delay *= 2; delay > maxReadyBackoff {
+			delay = maxReadyBackoff
+		}
+	}
+}
+
+// health checks the server is healthy, v8 has only a ping endpoint outside of projects
+func (d API[R, W, PK]) health(ctx context.Context) error {
+	if d.Version == V8 {
+		req := request{
+			ctx,
+			http.MethodGet,
+			fmt.Sprintf("%s://%s/server/ping", d.Scheme, d.Host),
+			nil,
+			nil,
+		}
+		return d.executeRequest(req, http.StatusOK, &bytes.Buffer{})
+	}
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/server/health",
+		nil,
+		nil,
+	}
+	var respBody struct {
+		Status string `json:"status"`
+	}
+	if err := d.executeRequest(req, http.StatusOK, &respBody); err != nil {
+		return err
+	}
+	if respBody.Status == "error" {
+		return errors.New("server is not healthy")
+	}
+	return nil
+}
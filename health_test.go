@@ -0,0 +1,53 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitReady(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/server/health", r.URL.Path)
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"error"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"warn"}`))
+		}
+	}))
+	api.Version = V9
+	api.RetryBackoff = time.Millisecond
+
+	require.NoError(t, api.AwaitReady(context.Background(), time.Second))
+	assert.EqualValues(t, 3, calls)
+
+	t.Run("timeout", func(t *testing.T) {
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		api.Version = V9
+		api.RetryBackoff = time.Millisecond
+
+		err := api.AwaitReady(context.Background(), 50*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "503")
+	})
+
+	t.Run("v8", func(t *testing.T) {
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/server/ping", r.URL.Path)
+			_, _ = w.Write([]byte("pong"))
+		}))
+		require.NoError(t, api.AwaitReady(context.Background(), time.Second))
+	})
+}
@@ -0,0 +1,133 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// FieldChange is a change of a single field of an item,
+// Old is nil for fields set when the item was created
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+	// Actor is the id of the user who made the change
+	Actor string
+	Time  time.Time
+}
+
+// History retrieves revisions of an item and returns changes of its fields from the oldest,
+// it's meant for "what changed" views and audit notifications
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/revisions.html#list-revisions
+// https://v8.docs.directus.io/api/revisions.html#list-revisions
+func (d API[R, W, PK]) History(ctx context.Context, id PK) ([]FieldChange, error) {
+	actorField, timeField := "activity.user", "activity.timestamp"
+	if d.Version == V8 {
+		actorField, timeField = "activity.action_by", "activity.action_on"
+	}
+	pk, err := formatPK(id)
+	if err != nil {
+		return nil, err
+	}
+	// revisions hold full data of the item, so they are scoped like the item itself
+	if err := d.checkScope(ctx, id); err != nil {
+		return nil, err
+	}
+	q := Eq("collection", d.CollectionName).Eq("item", pk).SortAsc("id").Limit(AllItems)
+	qv := q.asKeyValueAt(d.Version, d.clock().Now())
+	qv["fields"] = "id,data,delta," + actorField + "," + timeField
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/revisions",
+		qv,
+		nil,
+	}
+	var respBody struct {
+		Data []historyRevision `json:"data"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute history request: %w", err)
+	}
+
+	var changes []FieldChange
+	state := map[string]any{}
+	for _, rev := range respBody.Data {
+		c, err := rev.changes(state)
+		if err != nil {
+			return nil, fmt.Errorf("revision %d: %w", rev.ID, err)
+		}
+		changes = append(changes, c...)
+		if rev.Data != nil {
+			state = rev.Data
+		} else {
+			for k, v := range rev.Delta {
+				state[k] = v
+			}
+		}
+	}
+	return changes, nil
+}
+
+type historyRevision struct {
+	ID       int            `json:"id"`
+	Data     map[string]any `json:"data"`
+	Delta    map[string]any `json:"delta"`
+	Activity struct {
+		// v9 fields
+		User      json.RawMessage `json:"user"`
+		Timestamp string          `json:"timestamp"`
+		// v8 fields
+		ActionBy json.RawMessage `json:"action_by"`
+		ActionOn string          `json:"action_on"`
+	} `json:"activity"`
+}
+
+// changes returns changes of the revision against the previous state of the item sorted by field
+func (r historyRevision) changes(state map[string]any) ([]FieldChange, error) {
+	actor, err := rawString(r.Activity.User)
+	if err != nil {
+		return nil, fmt.Errorf("decode activity user: %w", err)
+	}
+	ts := r.Activity.Timestamp
+	if actor == "" && ts == "" {
+		if actor, err = rawString(r.Activity.ActionBy); err != nil {
+			return nil, fmt.Errorf("decode activity user: %w", err)
+		}
+		ts = r.Activity.ActionOn
+	}
+	var at time.Time
+	if ts != "" {
+		if at, err = parseTimestamp(ts); err != nil {
+			return nil, fmt.Errorf("parse activity time: %w", err)
+		}
+	}
+
+	delta := r.Delta
+	if delta == nil {
+		delta = r.Data
+	}
+	fields := make([]string, 0, len(delta))
+	for k := range delta {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	var out []FieldChange
+	for _, f := range fields {
+		old, nv := state[f], delta[f]
+		if reflect.DeepEqual(old, nv) {
+			continue
+		}
+		out = append(out, FieldChange{f, old, nv, actor, at})
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/revisions", r.URL.Path)
+		qv := r.URL.Query()
+		assert.Equal(t, "fruits", qv.Get("filter[collection][_eq]"))
+		assert.Equal(t, "1", qv.Get("filter[item][_eq]"))
+		assert.Equal(t, "id", qv.Get("sort"))
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":10,"data":{"id":1,"name":"kiwi","status":"draft"},"delta":{"id":1,"name":"kiwi","status":"draft"},
+				"activity":{"user":"u1","timestamp":"2022-05-01T10:00:00Z"}},
+			{"id":12,"data":{"id":1,"name":"kiwi","status":"published"},"delta":{"name":"kiwi","status":"published"},
+				"activity":{"user":"u2","timestamp":"2022-05-02T10:00:00Z"}}
+		]}`))
+	}))
+	api.Version = V9
+
+	changes, err := api.History(context.Background(), 1)
+	require.NoError(t, err)
+	created := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	updated := time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, []FieldChange{
+		{"id", nil, float64(1), "u1", created},
+		{"name", nil, "kiwi", "u1", created},
+		{"status", nil, "draft", "u1", created},
+		{"status", "draft", "published", "u2", updated},
+	}, changes)
+}
+
+func TestHistoryV8(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Query().Get("fields"), "activity.action_by")
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":3,"data":{"id":1,"name":"apple"},"delta":{"name":"apple"},
+				"activity":{"action_by":7,"action_on":"2022-05-02 10:00:00"}}
+		]}`))
+	}))
+
+	changes, err := api.History(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "7", changes[0].Actor)
+	assert.Equal(t, "apple", changes[0].New)
+	assert.Equal(t, 2022, changes[0].Time.Year())
+}
+
+func TestHistoryTenantScope(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		switch r.URL.Path {
+		case "/_/items/fruits":
+			assert.Equal(t, "acme", qv.Get("filter[status][_eq]"))
+			if qv.Get("filter[id][_eq]") == "2" {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":1}]}`))
+		case "/_/revisions":
+			assert.Equal(t, "1", qv.Get("filter[item][_eq]"), "revisions of other tenants are never requested")
+			_, _ = w.Write([]byte(`{"data":[{"id":10,"data":{"id":1},"delta":{"id":1},"activity":{"user":"u1","timestamp":"2022-05-01T10:00:00Z"}}]}`))
+		}
+	}))
+	api.Version = V9
+	api.Tenant = &TenantScope{Field: "status"}
+	ctx := WithTenant(context.Background(), "acme")
+
+	_, err := api.History(ctx, 2)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+	_, err = api.History(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNoTenant)
+	changes, err := api.History(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
@@ -0,0 +1,292 @@
+package directusapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TypeHook decodes and encodes values of T, e.g. types of other packages which can't implement
+// json.Unmarshaler or column types Directus serializes in an exotic way.
+// Either function is optional, encoding/json is used when it's nil.
+type TypeHook[T any] struct {
+	Decode func(data json.RawMessage) (T, error)
+	Encode func(v T) (json.RawMessage, error)
+}
+
+type typeHook struct {
+	decode func(data json.RawMessage) (reflect.Value, error)
+	encode func(v reflect.Value) (json.RawMessage, error)
+}
+
+// typeHooks holds registered hooks keyed by reflect.Type
+var typeHooks sync.Map
+
+// RegisterTypeHook registers a hook of T for fields of read and write models of all API instances,
+// it's meant to be called from init functions. Fields of type T are requested as a whole,
+// models with a registered Codec don't use hooks.
+func RegisterTypeHook[T any](h TypeHook[T]) {
+	var hook typeHook
+	if h.Decode != nil {
+		hook.decode = func(data json.RawMessage) (reflect.Value, error) {
+			v, err := h.Decode(data)
+			return reflect.ValueOf(&v).Elem(), err
+		}
+	}
+	if h.Encode != nil {
+		hook.encode = func(v reflect.Value) (json.RawMessage, error) {
+			return h.Encode(v.Interface().(T))
+		}
+	}
+	typeHooks.Store(reflect.TypeOf((*T)(nil)).Elem(), hook)
+	hookedTypes.Range(func(k, _ any) bool {
+		hookedTypes.Delete(k)
+		return true
+	})
+	// hooked types change fields of read models
+	readFields.Range(func(k, _ any) bool {
+		readFields.Delete(k)
+		return true
+	})
+}
+
+func lookupTypeHook(t reflect.Type) (typeHook, bool) {
+	h, ok := typeHooks.Load(t)
+	if !ok {
+		return typeHook{}, false
+	}
+	return h.(typeHook), true
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+type hookedKey struct {
+	t, opaque reflect.Type
+}
+
+// hookedTypes caches results of hooked, it's reset when a hook is registered
+var hookedTypes sync.Map
+
+// hooked reports whether values of t contain a type with a registered hook or, when encoding,
+// a Relation, types with their own JSON methods of the given interface are opaque
+func hooked(t reflect.Type, opaque reflect.Type) bool {
+	k := hookedKey{t, opaque}
+	if h, ok := hookedTypes.Load(k); ok {
+		return h.(bool)
+	}
+	h := hookedType(t, opaque, map[reflect.Type]bool{})
+	hookedTypes.Store(k, h)
+	return h
+}
+
+func hookedType(t reflect.Type, opaque reflect.Type, visited map[reflect.Type]bool) bool {
+	if _, ok := lookupTypeHook(t); ok {
+		return true
+	}
+	if opaque == marshalerType && t.Implements(relationType) {
+		// unchanged relations are left out of the encoded struct
+		return true
+	}
+	if visited[t] || reflect.PtrTo(t).Implements(opaque) {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return hookedType(t.Elem(), opaque, visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && hookedType(f.Type, opaque, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonName returns the JSON name of a struct field, skip is set for fields ignored by encoding/json
+func jsonName(f reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := f.Tag.Lookup(tagName)
+	if tag == "-" || !f.IsExported() {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if !ok || name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), false
+}
+
+// decodeHooked decodes data into v like encoding/json, values of types with a registered hook are decoded by it
+func decodeHooked(data json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	if h, ok := lookupTypeHook(t); ok && h.decode != nil {
+		hv, err := h.decode(data)
+		if err != nil {
+			return err
+		}
+		v.Set(hv)
+		return nil
+	}
+	if !hooked(t, unmarshalerType) || string(data) == "null" {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decodeHooked(data, v.Elem())
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := decodeHooked(item, s.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(t, len(items))
+		for k, item := range items {
+			ev := reflect.New(t.Elem()).Elem()
+			if err := decodeHooked(item, ev); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), ev)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for i := 0; i < t.NumField(); i++ {
+			name, _, skip := jsonName(t.Field(i))
+			if skip {
+				continue
+			}
+			raw, ok := fields[name]
+			if !ok {
+				// encoding/json matches names case-insensitively
+				for k, r := range fields {
+					if strings.EqualFold(k, name) {
+						raw, ok = r, true
+						break
+					}
+				}
+			}
+			if !ok {
+				continue
+			}
+			if err := decodeHooked(raw, v.Field(i)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	return nil
+}
+
+// encodeHooked encodes v like encoding/json, values of types with a registered hook are encoded by it
+// and unchanged relations are left out
+func encodeHooked(v reflect.Value) (json.RawMessage, error) {
+	t := v.Type()
+	if h, ok := lookupTypeHook(t); ok && h.encode != nil {
+		return h.encode(v)
+	}
+	if !hooked(t, marshalerType) || t.Implements(relationType) {
+		return json.Marshal(v.Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		return encodeHooked(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		items := make([]json.RawMessage, v.Len())
+		for i := range items {
+			var err error
+			if items[i], err = encodeHooked(v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(items)
+	case reflect.Map:
+		if v.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		items := make(map[string]json.RawMessage, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			item, err := encodeHooked(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			items[fmt.Sprint(iter.Key().Interface())] = item
+		}
+		return json.Marshal(items)
+	case reflect.Struct:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i := 0; i < t.NumField(); i++ {
+			name, omitEmpty, skip := jsonName(t.Field(i))
+			if skip || omitEmpty && emptyValue(v.Field(i)) || unchangedRelation(v.Field(i)) {
+				continue
+			}
+			fv, err := encodeHooked(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(name)
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(fv)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+	return json.Marshal(v.Interface())
+}
+
+// emptyValue reports values left out by the omitempty option of encoding/json
+func emptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
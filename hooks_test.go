@@ -0,0 +1,78 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookPoint stands for a type of another package stored as WKT by Directus
+type hookPoint struct {
+	X, Y float64
+}
+
+type hookPlace struct {
+	ID       int         `json:"id"`
+	Location hookPoint   `json:"location"`
+	Route    []hookPoint `json:"route"`
+	Name     string
+}
+
+type hookPlaceW struct {
+	Location hookPoint  `json:"location"`
+	Previous *hookPoint `json:"previous,omitempty"`
+	Note     string     `json:"note,omitempty"`
+}
+
+func TestTypeHook(t *testing.T) {
+	RegisterTypeHook(TypeHook[hookPoint]{
+		Decode: func(data json.RawMessage) (hookPoint, error) {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return hookPoint{}, err
+			}
+			var p hookPoint
+			_, err := fmt.Sscanf(s, "POINT(%g %g)", &p.X, &p.Y)
+			return p, err
+		},
+		Encode: func(p hookPoint) (json.RawMessage, error) {
+			return json.Marshal(fmt.Sprintf("POINT(%g %g)", p.X, p.Y))
+		},
+	})
+
+	var body string
+	api := API[hookPlace, hookPlaceW, int]{
+		Scheme:         "http",
+		Host:           "localhost:8080",
+		Namespace:      "_",
+		CollectionName: "places",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "id,location,route,Name", r.URL.Query().Get("fields"))
+			if r.Method == http.MethodPost {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				return jsonResponse(http.StatusOK, `{"data":{"id":2,"location":"POINT(3 4)"}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"id":1,"location":"POINT(1.5 2)","route":["POINT(0 0)","POINT(1 1)"],"name":"home"}]}`), nil
+		})},
+	}
+
+	places, err := api.Items(context.Background(), None())
+	require.NoError(t, err)
+	assert.Equal(t, []hookPlace{{1, hookPoint{1.5, 2}, []hookPoint{{0, 0}, {1, 1}}, "home"}}, places)
+
+	place, err := api.Insert(context.Background(), hookPlaceW{Location: hookPoint{3, 4}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"location":"POINT(3 4)"}`, body)
+	assert.Equal(t, hookPoint{3, 4}, place.Location)
+
+	_, err = api.Insert(context.Background(), hookPlaceW{Location: hookPoint{3, 4}, Previous: &hookPoint{1, 2}, Note: "moved"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"location":"POINT(3 4)","previous":"POINT(1 2)","note":"moved"}`, body)
+}
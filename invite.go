@@ -0,0 +1,51 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// InviteUser sends an invitation email to a new user with the given role,
+// inviteURL is the page of the application the link points to with the token in its query.
+// It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#invite-a-new-user
+func (d API[R, W, PK]) InviteUser(ctx context.Context, email, roleID, inviteURL string) error {
+	body := map[string]string{"email": email, "role": roleID}
+	if inviteURL != "" {
+		body["invite_url"] = inviteURL
+	}
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/users/invite",
+		nil,
+		body,
+	}
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute invite user request: %w", err)
+	}
+	return nil
+}
+
+// AcceptInvite activates an invited user identified by the token from the invitation link and sets their password
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#accept-user-invite
+func (d API[R, W, PK]) AcceptInvite(ctx context.Context, token, password string) error {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/users/invite/accept",
+		nil,
+		map[string]string{"token": token, "password": password},
+	}
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute accept invite request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteUser(t *testing.T) {
+	bodies := map[string]map[string]string{}
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies[r.URL.Path] = body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	api.Version = V9
+	ctx := context.Background()
+
+	require.NoError(t, api.InviteUser(ctx, "new@example.com", "role-1", "https://example.com/accept"))
+	require.NoError(t, api.AcceptInvite(ctx, "token", "secret"))
+	assert.Equal(t, map[string]map[string]string{
+		"/_/users/invite":        {"email": "new@example.com", "role": "role-1", "invite_url": "https://example.com/accept"},
+		"/_/users/invite/accept": {"token": "token", "password": "secret"},
+	}, bodies)
+}
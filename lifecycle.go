@@ -0,0 +1,111 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed is returned by requests started after Close was called
+var ErrClosed = errors.New("directusapi: client is closed")
+
+// Lifecycle tracks in-flight requests and background goroutines of all API instances sharing it,
+// so they can be shut down gracefully with Close. Zero value is ready to use.
+type Lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+	// cancels contexts of background goroutines, created lazily
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+}
+
+// acquire registers an in-flight request, it fails once the lifecycle is closed
+func (l *Lifecycle) acquire() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ErrClosed
+	}
+	l.wg.Add(1)
+	return nil
+}
+
+func (l *Lifecycle) release() {
+	if l == nil {
+		return
+	}
+	l.wg.Done()
+}
+
+// background registers a background goroutine. Returned context is canceled
+// when ctx is done or Close is called, done has to be called once the goroutine exits.
+func (l *Lifecycle) background(ctx context.Context) (context.Context, func(), error) {
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrClosed
+	}
+	if l.bgCtx == nil {
+		l.bgCtx, l.bgCancel = context.WithCancel(context.Background())
+	}
+	l.wg.Add(1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	bgCtx := l.bgCtx
+	go func() {
+		select {
+		case <-bgCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		cancel()
+		l.wg.Done()
+	}, nil
+}
+
+// Close stops accepting new requests, cancels background goroutines and waits
+// for in-flight requests to finish. It returns ctx error when the wait is cut by ctx.
+func (l *Lifecycle) Close(ctx context.Context) error {
+	l.mu.Lock()
+	l.closed = true
+	if l.bgCancel != nil {
+		l.bgCancel()
+	}
+	l.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for in-flight requests: %w", ctx.Err())
+	}
+}
+
+// Close gracefully shuts the client down. It stops accepting new requests and waits for in-flight
+// requests and background goroutines tracked by Lifecycle up to ctx deadline, then releases idle connections
+// of HTTPClient. http.DefaultClient used without HTTPClient is shared by the process, so it's left open.
+func (d API[R, W, PK]) Close(ctx context.Context) error {
+	var err error
+	if d.Lifecycle != nil {
+		err = d.Lifecycle.Close(ctx)
+	}
+	if d.HTTPClient != nil {
+		d.HTTPClient.CloseIdleConnections()
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClose(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	api.Lifecycle = &Lifecycle{}
+
+	inFlight := make(chan error)
+	go func() {
+		_, err := api.GetByID(context.Background(), 1)
+		inFlight <- err
+	}()
+	<-started
+
+	events, err := api.WatchPublish(context.Background(), None(), time.Hour, func(f FruitR) int { return f.ID }, nil)
+	require.NoError(t, err)
+
+	// deadline is hit while the request is still in-flight
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = api.Close(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = api.GetByID(context.Background(), 1)
+	assert.True(t, errors.Is(err, ErrClosed))
+
+	close(unblock)
+	require.NoError(t, <-inFlight)
+	require.NoError(t, api.Close(context.Background()))
+
+	_, open := <-events
+	assert.False(t, open, "watcher should be stopped by Close")
+}
+
+// idleTransport counts released idle connections
+type idleTransport struct {
+	http.RoundTripper
+	closed int
+}
+
+func (t *idleTransport) CloseIdleConnections() {
+	t.closed++
+}
+
+func TestCloseIdleConnections(t *testing.T) {
+	own := &idleTransport{}
+	api := API[FruitR, FruitW, int]{HTTPClient: &http.Client{Transport: own}}
+	require.NoError(t, api.Close(context.Background()))
+	assert.Equal(t, 1, own.closed)
+
+	// connections of the default client are shared by the whole process
+	shared := &idleTransport{}
+	defaultTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = shared
+	defer func() { http.DefaultClient.Transport = defaultTransport }()
+	require.NoError(t, API[FruitR, FruitW, int]{}.Close(context.Background()))
+	assert.Zero(t, shared.closed)
+}
@@ -0,0 +1,122 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ClientConfig is a part of the client configuration which can be changed at runtime by LiveConfig,
+// zero fields keep the settings of the API
+type ClientConfig struct {
+	// Scheme, Host and Namespace replace those of the API when Host is set, Scheme defaults to https
+	Scheme    string `json:"scheme,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Token takes precedence over TokenProvider, Auth, TokenRefresher and BearerToken,
+	// only tokens set by WithToken of the context or of an API copy override it
+	Token string `json:"token,omitempty"`
+	// RateLimitThreshold replaces Threshold of RateLimiter
+	RateLimitThreshold float64 `json:"rate_limit_threshold,omitempty"`
+}
+
+// LiveConfig holds a ClientConfig which is reloaded without recreating API instances, e.g. by a callback
+// of a config service or by WatchConfigFile. Set it to API.LiveConfig of all instances which should follow it,
+// requests started after Reload use the new config. Zero value is ready to use.
+type LiveConfig struct {
+	// OnReload is optional, it's called with every reloaded config
+	OnReload func(ClientConfig)
+
+	mu  sync.RWMutex
+	cfg ClientConfig
+}
+
+// Config returns the current config, it's empty for nil LiveConfig
+func (c *LiveConfig) Config() ClientConfig {
+	if c == nil {
+		return ClientConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
+// Reload replaces the current config by cfg
+func (c *LiveConfig) Reload(cfg ClientConfig) error {
+	if cfg.RateLimitThreshold < 0 || cfg.RateLimitThreshold > 1 {
+		return fmt.Errorf("rate limit threshold has to be between 0 and 1, got %g", cfg.RateLimitThreshold)
+	}
+	c.mu.Lock()
+	c.cfg = cfg
+	c.mu.Unlock()
+	if c.OnReload != nil {
+		c.OnReload(cfg)
+	}
+	return nil
+}
+
+// loadFile reloads the config from a JSON file
+func (c *LiveConfig) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var cfg ClientConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("decode config %s: %w", path, err)
+	}
+	return c.Reload(cfg)
+}
+
+// WatchConfigFile loads LiveConfig of the API from a JSON file of ClientConfig and reloads it whenever
+// the file changes, the file is checked every interval. The first load fails synchronously, failures of reloads
+// are reported to OnWarning and the previous config is kept. Watching stops once ctx is done or the client is closed.
+func (d API[R, W, PK]) WatchConfigFile(ctx context.Context, path string, interval time.Duration) error {
+	if d.LiveConfig == nil {
+		return errors.New("watch config file: LiveConfig is not set")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("watch config file: interval has to be positive, got %s", interval)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("watch config file: %w", err)
+	}
+	if err := d.LiveConfig.loadFile(path); err != nil {
+		return fmt.Errorf("watch config file: %w", err)
+	}
+	ctx, done, err := d.Lifecycle.background(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer done()
+		timer := d.clock().NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C():
+				timer.Reset(interval)
+			case <-ctx.Done():
+				return
+			}
+			current, err := os.Stat(path)
+			if err != nil {
+				d.warn(fmt.Errorf("watch config file: %w", err))
+				continue
+			}
+			if current.ModTime().Equal(stat.ModTime()) && current.Size() == stat.Size() {
+				continue
+			}
+			stat = current
+			if err := d.LiveConfig.loadFile(path); err != nil {
+				d.warn(fmt.Errorf("watch config file: %w", err))
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,102 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveConfig(t *testing.T) {
+	var auth []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, "a "+r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/items/fruits/1", r.URL.Path)
+		auth = append(auth, "b "+r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	defer other.Close()
+	api.BearerToken = "static"
+	var reloaded []ClientConfig
+	api.LiveConfig = &LiveConfig{OnReload: func(cfg ClientConfig) { reloaded = append(reloaded, cfg) }}
+
+	ctx := context.Background()
+	_, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	cfg := ClientConfig{Scheme: "http", Host: strings.TrimPrefix(other.URL, "http://"), Namespace: "v2", Token: "rotated"}
+	require.NoError(t, api.LiveConfig.Reload(cfg))
+	_, err = api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = api.GetByID(WithToken(ctx, "user"), 1)
+	require.NoError(t, err)
+	// a copy with a user token never falls back to the token of the config
+	_, err = api.WithToken("copy").GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a Bearer static", "b Bearer rotated", "b Bearer user", "b Bearer copy"}, auth)
+	assert.Equal(t, []ClientConfig{cfg}, reloaded)
+	assert.Error(t, api.LiveConfig.Reload(ClientConfig{RateLimitThreshold: 2}))
+	assert.Equal(t, cfg, api.LiveConfig.Config())
+	assert.Zero(t, (*LiveConfig)(nil).Config())
+}
+
+func TestLiveConfigRateLimitThreshold(t *testing.T) {
+	now := time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC)
+	l := RateLimiter{state: RateLimit{100, 30, now.Add(10 * time.Second)}}
+	assert.Zero(t, l.delayBelow(now, 0), "30% is above the default threshold")
+	assert.Equal(t, 10*time.Second/31, l.delayBelow(now, 0.5))
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "directus.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"host":"a.example.com","token":"first"}`), 0o600))
+
+	var mu sync.Mutex
+	var warnings []error
+	clock := NewFakeClock(time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC))
+	api := API[FruitR, FruitW, int]{
+		Clock:      clock,
+		LiveConfig: &LiveConfig{},
+		Lifecycle:  &Lifecycle{},
+		OnWarning: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, err)
+		},
+	}
+	assert.Error(t, api.WatchConfigFile(context.Background(), filepath.Join(t.TempDir(), "missing.json"), time.Second))
+	assert.Error(t, api.WatchConfigFile(context.Background(), path, 0))
+	require.NoError(t, api.WatchConfigFile(context.Background(), path, time.Second))
+	assert.Equal(t, "https://a.example.com", api.baseURL())
+	require.Eventually(t, func() bool { return clock.Timers() == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"host":"b.example.com","token":"second"}`), 0o600))
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool { return api.LiveConfig.Config().Token == "second" }, time.Second, time.Millisecond)
+	assert.Equal(t, "https://b.example.com", api.baseURL())
+
+	// broken configs keep the previous one
+	require.NoError(t, os.WriteFile(path, []byte(`{"host":`), 0o600))
+	require.Eventually(t, func() bool { return clock.Timers() == 1 }, time.Second, time.Millisecond)
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(warnings) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "second", api.LiveConfig.Config().Token)
+
+	require.NoError(t, api.Close(context.Background()))
+}
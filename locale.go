@@ -0,0 +1,44 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// translationsRelation is the relation created by the translations interface of Directus
+	translationsRelation = "translations"
+	// languageCodeField is the field of translations rows referencing their language
+	languageCodeField = "languages_code"
+)
+
+type localeCtxKey struct{}
+
+type locale struct {
+	code      string
+	relations []string
+}
+
+// WithLocale returns a context requesting content in the given locale, e.g. de-DE.
+// Requests get the Accept-Language header and with v9 the translations relations of read items
+// are filtered by languages_code, so strings of other languages aren't transferred.
+// Relations default to translations, nested relations are separated by a dot.
+func WithLocale(ctx context.Context, code string, relations ...string) context.Context {
+	if len(relations) == 0 {
+		relations = []string{translationsRelation}
+	}
+	ctx = withHeaders(ctx, map[string]string{"Accept-Language": code})
+	return context.WithValue(ctx, localeCtxKey{}, locale{code, relations})
+}
+
+// localize adds the deep filters of the locale of ctx to params of a read request,
+// v8 doesn't support deep filters so it relies on the header only
+func (d API[R, W, PK]) localize(ctx context.Context, qv map[string]string) {
+	l, ok := ctx.Value(localeCtxKey{}).(locale)
+	if !ok || d.Version == V8 {
+		return
+	}
+	for _, rel := range l.relations {
+		qv[fmt.Sprintf("deep%s[_filter][%s][_eq]", parseV9Path(rel), languageCodeField)] = l.code
+	}
+}
@@ -0,0 +1,49 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLocale(t *testing.T) {
+	var header string
+	var params map[string][]string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Accept-Language")
+		params = r.URL.Query()
+		if r.URL.Path == "/_/items/fruits" {
+			_, _ = w.Write([]byte(`{"data":[{"id":1}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.Version = V9
+
+	ctx := WithLocale(context.Background(), "de-DE")
+	_, err := api.Items(ctx, None())
+	require.NoError(t, err)
+	assert.Equal(t, "de-DE", header)
+	assert.Equal(t, []string{"de-DE"}, params["deep[translations][_filter][languages_code][_eq]"])
+
+	ctx = WithLocale(context.Background(), "hr-HR", "lefield.translations")
+	_, err = api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "hr-HR", header)
+	assert.Equal(t, []string{"hr-HR"}, params["deep[lefield][translations][_filter][languages_code][_eq]"])
+	assert.NotContains(t, params, "deep[translations][_filter][languages_code][_eq]")
+
+	t.Run("v8", func(t *testing.T) {
+		api := api
+		api.Version = V8
+		_, err := api.Items(WithLocale(context.Background(), "de-DE"), None())
+		require.NoError(t, err)
+		assert.Equal(t, "de-DE", header)
+		for k := range params {
+			assert.NotContains(t, k, "deep")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// MappedAPI is an API returning domain types D instead of read models, so Directus models
+// stay out of domain layers. It implements Collection of D.
+type MappedAPI[D, R, W any, PK PrimaryKey] struct {
+	API   API[R, W, PK]
+	mapFn func(R) (D, error)
+}
+
+var _ Collection[struct{}, struct{}, int] = MappedAPI[struct{}, struct{}, struct{}, int]{}
+
+// MapAPI returns api whose read items are mapped by fn, items failing to map fail the whole call
+func MapAPI[D, R, W any, PK PrimaryKey](api API[R, W, PK], fn func(R) (D, error)) MappedAPI[D, R, W, PK] {
+	return MappedAPI[D, R, W, PK]{api, fn}
+}
+
+func (m MappedAPI[D, R, W, PK]) mapItem(item R, err error) (D, error) {
+	if err != nil {
+		var empty D
+		return empty, err
+	}
+	d, err := m.mapFn(item)
+	if err != nil {
+		return d, fmt.Errorf("map item: %w", err)
+	}
+	return d, nil
+}
+
+func (m MappedAPI[D, R, W, PK]) mapItems(items []R) ([]D, error) {
+	out := make([]D, len(items))
+	for i, item := range items {
+		d, err := m.mapFn(item)
+		if err != nil {
+			return nil, fmt.Errorf("map item %d: %w", i, err)
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+func (m MappedAPI[D, R, W, PK]) Insert(ctx context.Context, item W) (D, error) {
+	return m.mapItem(m.API.Insert(ctx, item))
+}
+
+func (m MappedAPI[D, R, W, PK]) Create(ctx context.Context, partials map[string]any) (D, error) {
+	return m.mapItem(m.API.Create(ctx, partials))
+}
+
+func (m MappedAPI[D, R, W, PK]) GetByID(ctx context.Context, id PK) (D, error) {
+	return m.mapItem(m.API.GetByID(ctx, id))
+}
+
+func (m MappedAPI[D, R, W, PK]) Update(ctx context.Context, id PK, partials map[string]any) (D, error) {
+	return m.mapItem(m.API.Update(ctx, id, partials))
+}
+
+func (m MappedAPI[D, R, W, PK]) Set(ctx context.Context, id PK, item W) (D, error) {
+	return m.mapItem(m.API.Set(ctx, id, item))
+}
+
+func (m MappedAPI[D, R, W, PK]) Delete(ctx context.Context, id PK) error {
+	return m.API.Delete(ctx, id)
+}
+
+func (m MappedAPI[D, R, W, PK]) Items(ctx context.Context, q Query) ([]D, error) {
+	items, err := m.API.Items(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return m.mapItems(items)
+}
+
+func (m MappedAPI[D, R, W, PK]) ItemsWithMeta(ctx context.Context, q Query) ([]D, ItemsMeta, error) {
+	items, meta, err := m.API.ItemsWithMeta(ctx, q)
+	if err != nil {
+		return nil, meta, err
+	}
+	mapped, err := m.mapItems(items)
+	return mapped, meta, err
+}
@@ -0,0 +1,39 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fruit struct {
+	Label string
+}
+
+func TestMapAPI(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/items/fruits" {
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"},{"id":2,"name":""}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	fruits := MapAPI(api, func(f FruitR) (fruit, error) {
+		if f.Name == "" {
+			return fruit{}, errors.New("fruit without a name")
+		}
+		return fruit{f.Name}, nil
+	})
+	var _ Collection[fruit, FruitW, int] = fruits
+
+	f, err := fruits.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, fruit{"kiwi"}, f)
+
+	_, err = fruits.Items(context.Background(), None())
+	assert.EqualError(t, err, "map item 1: fruit without a name")
+}
@@ -0,0 +1,111 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// MergeStrategy resolves fields changed both on the server and by the client
+type MergeStrategy uint8
+
+const (
+	// ServerWins keeps the server value of conflicting fields
+	ServerWins MergeStrategy = iota
+	// ClientWins overwrites conflicting fields with the client value
+	ClientWins
+	// NewestWins takes the value of the side which changed the item later,
+	// the server side change time is its date_updated (modified_on for v8)
+	NewestWins
+)
+
+// Merge three-way merges client changes of an item with its current server state.
+// base is the item as the client read it, server is its current state and client contains
+// fields changed by the client. Fields changed only on one side are always kept,
+// conflicting fields are resolved by strategy. It returns partials to be sent to the server,
+// fields which already have the merged value on the server are left out.
+func Merge(strategy MergeStrategy, base, server, client map[string]any, serverUpdated, clientUpdated time.Time) map[string]any {
+	base, server, client = normalizeJSON(base), normalizeJSON(server), normalizeJSON(client)
+	out := map[string]any{}
+	for k, cv := range client {
+		sv, onServer := server[k]
+		if onServer && reflect.DeepEqual(sv, cv) {
+			continue
+		}
+		bv, inBase := base[k]
+		serverChanged := onServer && inBase && !reflect.DeepEqual(bv, sv)
+		if !serverChanged {
+			out[k] = cv
+			continue
+		}
+		switch strategy {
+		case ClientWins:
+			out[k] = cv
+		case NewestWins:
+			if clientUpdated.After(serverUpdated) {
+				out[k] = cv
+			}
+		}
+	}
+	return out
+}
+
+// normalizeJSON converts values to their JSON decoded form, so they can be compared with server values
+func normalizeJSON(m map[string]any) map[string]any {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return m
+	}
+	return out
+}
+
+// UpdateMerged updates an item with client changes merged with its current server state by Merge.
+// base is the item as the client read it and changedAt is when the client made the changes.
+func (d API[R, W, PK]) UpdateMerged(ctx context.Context, id PK, base, changes map[string]any, changedAt time.Time, strategy MergeStrategy) (R, error) {
+	var empty R
+	updatedField := updatedFieldV9
+	if d.Version == V8 {
+		updatedField = updatedFieldV8
+	}
+
+	u, err := d.itemURL(id)
+	if err != nil {
+		return empty, err
+	}
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		map[string]string{
+			"fields": "*",
+		},
+		nil,
+	}
+	var respBody struct {
+		Data map[string]any `json:"data"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return empty, fmt.Errorf("execute get server state request: %w", err)
+	}
+
+	var serverUpdated time.Time
+	if s, ok := respBody.Data[updatedField].(string); ok {
+		if serverUpdated, err = parseTimestamp(s); err != nil {
+			return empty, fmt.Errorf("parse %s: %w", updatedField, err)
+		}
+	}
+
+	partials := Merge(strategy, base, respBody.Data, changes, serverUpdated, changedAt)
+	if len(partials) == 0 {
+		return d.GetByID(ctx, id)
+	}
+	return d.Update(ctx, id, partials)
+}
@@ -0,0 +1,25 @@
+package directusapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	base := map[string]any{"name": "kiwi", "weight": 10, "status": "draft"}
+	// the server changed weight and status, the client changed name and weight
+	server := map[string]any{"name": "kiwi", "weight": 12, "status": "published"}
+	client := map[string]any{"name": "gold kiwi", "weight": 11}
+	older := time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Minute)
+
+	assert.Equal(t, map[string]any{"name": "gold kiwi"}, Merge(ServerWins, base, server, client, older, newer))
+	assert.Equal(t, map[string]any{"name": "gold kiwi", "weight": float64(11)}, Merge(ClientWins, base, server, client, newer, older))
+	assert.Equal(t, map[string]any{"name": "gold kiwi", "weight": float64(11)}, Merge(NewestWins, base, server, client, older, newer))
+	assert.Equal(t, map[string]any{"name": "gold kiwi"}, Merge(NewestWins, base, server, client, newer, older))
+
+	// nothing to send when the server already has the client value
+	assert.Empty(t, Merge(ClientWins, base, server, map[string]any{"weight": 12}, older, newer))
+}
@@ -0,0 +1,95 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultMetadataTTL = 5 * time.Minute
+
+// MetadataCache caches collections and fields metadata, so features which need schema lookups
+// like LoadSchema or DumpProject don't hit the server repeatedly. It can be shared by API instances
+// of the same Directus instance, call Invalidate after the schema is changed. Metadata are cached
+// per token because the server returns only collections and fields the token is allowed to see.
+type MetadataCache struct {
+	// TTL is how long metadata are cached, defaults to 5 minutes
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[metadataKey]metadataEntry
+}
+
+// maxMetadataEntries bounds cached responses, e.g. of rotated tokens
+const maxMetadataEntries = 1024
+
+type metadataKey struct {
+	token, url string
+}
+
+type metadataEntry struct {
+	data    json.RawMessage
+	expires time.Time
+}
+
+// Invalidate drops all cached metadata
+func (c *MetadataCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+func (c *MetadataCache) get(k metadataKey, now time.Time) (json.RawMessage, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok || !now.Before(e.expires) {
+		return nil, false
+	}
+	return e.data, true
+}
+
+func (c *MetadataCache) set(k metadataKey, data json.RawMessage, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil || len(c.entries) >= maxMetadataEntries {
+		c.entries = map[metadataKey]metadataEntry{}
+	}
+	c.entries[k] = metadataEntry{data, now.Add(orDefault(c.TTL, defaultMetadataTTL))}
+}
+
+// getMetadata retrieves a metadata endpoint into dest, the response is cached by Metadata per token when it's set
+func (d API[R, W, PK]) getMetadata(ctx context.Context, u string, dest any) error {
+	token, err := d.bearerToken(ctx)
+	if err != nil {
+		return err
+	}
+	key := metadataKey{token, u}
+	data, ok := d.Metadata.get(key, d.clock().Now())
+	if !ok {
+		req := request{
+			ctx,
+			http.MethodGet,
+			u,
+			nil,
+			nil,
+		}
+		if err := d.executeRequest(req, http.StatusOK, &data); err != nil {
+			return err
+		}
+		d.Metadata.set(key, data, d.clock().Now())
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("decoding json response: %w", err)
+	}
+	return nil
+}
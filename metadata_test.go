@@ -0,0 +1,69 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCache(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/fields/fruits", r.URL.Path)
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"data":[{"field":"id","type":"integer"},{"field":"name","type":"string"}]}`))
+	}))
+	clock := NewFakeClock(time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC))
+	api.Clock = clock
+	api.Metadata = &MetadataCache{TTL: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		schema, err := api.LoadSchema(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "integer", schema.Fields["id"].Type)
+	}
+	assert.EqualValues(t, 1, calls)
+
+	clock.Advance(time.Minute)
+	_, err := api.LoadSchema(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls)
+
+	api.Metadata.Invalidate()
+	_, err = api.LoadSchema(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, calls)
+
+	api.Metadata = nil
+	_, err = api.LoadSchema(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, calls)
+}
+
+func TestMetadataCachePerToken(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") == "Bearer admin" {
+			_, _ = w.Write([]byte(`{"data":[{"field":"id","type":"integer"},{"field":"secret","type":"string"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"field":"id","type":"integer"}]}`))
+	}))
+	api.Metadata = &MetadataCache{}
+
+	admin, err := api.WithToken("admin").LoadSchema(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, admin.Fields, "secret")
+	user, err := api.LoadSchema(WithToken(context.Background(), "user"))
+	require.NoError(t, err)
+	assert.NotContains(t, user.Fields, "secret", "metadata of other tokens aren't shared")
+	_, err = api.WithToken("admin").LoadSchema(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls)
+}
@@ -0,0 +1,58 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MultiQuery executes queries of several collections concurrently, e.g. to render a page
+// combining articles, pages and products. Queries are added by AddQuery, so each of them
+// keeps the types of its collection.
+type MultiQuery struct {
+	// Timeout is a deadline shared by all queries, there is no deadline besides ctx when it's zero
+	Timeout time.Duration
+	queries []func(ctx context.Context) error
+}
+
+// AddQuery adds a query of api to m, dest is set to the retrieved items when Run succeeds
+func AddQuery[R, W any, PK PrimaryKey](m *MultiQuery, api API[R, W, PK], q Query, dest *[]R) {
+	m.queries = append(m.queries, func(ctx context.Context) error {
+		items, err := api.Items(ctx, q)
+		if err != nil {
+			return fmt.Errorf("query %s: %w", api.CollectionName, err)
+		}
+		*dest = items
+		return nil
+	})
+}
+
+// Run executes all added queries concurrently, the first failure cancels the others and is returned
+func (m *MultiQuery) Run(ctx context.Context) error {
+	if m.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Timeout)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for _, q := range m.queries {
+		wg.Add(1)
+		go func(q func(ctx context.Context) error) {
+			defer wg.Done()
+			if err := q(ctx); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(q)
+	}
+	wg.Wait()
+	return firstErr
+}
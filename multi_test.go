@@ -0,0 +1,53 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiQuery(t *testing.T) {
+	fruits := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_/items/fruits":
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"}]}`))
+		case "/_/users":
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","email":"admin@example.com"}]}`))
+		case "/_/items/slow":
+			<-r.Context().Done()
+		}
+	}))
+	users := UsersAPI{
+		Scheme:         fruits.Scheme,
+		Host:           fruits.Host,
+		Namespace:      fruits.Namespace,
+		CollectionName: CollectionUsers,
+		HTTPClient:     fruits.HTTPClient,
+		Version:        V9,
+	}
+
+	var m MultiQuery
+	var gotFruits []FruitR
+	var gotUsers []User
+	AddQuery(&m, fruits, None(), &gotFruits)
+	AddQuery(&m, users, None(), &gotUsers)
+	require.NoError(t, m.Run(context.Background()))
+	assert.Equal(t, "kiwi", gotFruits[0].Name)
+	assert.Equal(t, "admin@example.com", gotUsers[0].Email)
+
+	t.Run("timeout", func(t *testing.T) {
+		slow := fruits
+		slow.CollectionName = "slow"
+		m := MultiQuery{Timeout: 20 * time.Millisecond}
+		var gotSlow []FruitR
+		AddQuery(&m, fruits, None(), &gotFruits)
+		AddQuery(&m, slow, None(), &gotSlow)
+		err := m.Run(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "query slow")
+	})
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package directusapi
+
+const raceEnabled = false
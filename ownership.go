@@ -0,0 +1,122 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Ownership sets an owner field of inserted items to the user of the current token,
+// it's needed when permissions of the role check the field against $CURRENT_USER.
+// The user is resolved by /users/me once per token and cached, concurrent lookups of a token share one request.
+// Requests without a bearer token, e.g. authenticated by a session cookie, resolve the user every time.
+type Ownership struct {
+	// Field holds the owner of an item, e.g. owner or user_created
+	Field string
+
+	mu    sync.Mutex
+	users map[string]*ownerLookup
+}
+
+// maxOwnerTokens bounds users cached by Ownership, e.g. of rotated tokens
+const maxOwnerTokens = 1024
+
+// ownerLookup resolves the user of a token, done is closed once userID or err is set
+type ownerLookup struct {
+	done   chan struct{}
+	userID json.RawMessage
+	err    error
+}
+
+// lookup returns the lookup of token and whether the caller has to resolve it
+func (o *Ownership) lookup(token string) (*ownerLookup, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if l, ok := o.users[token]; ok {
+		return l, false
+	}
+	if o.users == nil || len(o.users) >= maxOwnerTokens {
+		o.users = map[string]*ownerLookup{}
+	}
+	l := &ownerLookup{done: make(chan struct{})}
+	o.users[token] = l
+	return l, true
+}
+
+// forget drops a failed lookup, so the next call resolves the user again
+func (o *Ownership) forget(token string, l *ownerLookup) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.users[token] == l {
+		delete(o.users, token)
+	}
+}
+
+// CurrentUserID returns the id of the user of the current token, it's a string for v9 and a number for v8
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#retrieve-the-current-user
+// https://v8.docs.directus.io/api/users.html#retrieve-the-current-user
+func (d API[R, W, PK]) CurrentUserID(ctx context.Context) (json.RawMessage, error) {
+	if d.Owner == nil {
+		return d.currentUserID(ctx)
+	}
+	token, err := d.bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		// callers authenticated by session cookies share the empty token, they aren't cached
+		return d.currentUserID(ctx)
+	}
+	l, resolve := d.Owner.lookup(token)
+	if resolve {
+		l.userID, l.err = d.currentUserID(ctx)
+		if l.err != nil {
+			d.Owner.forget(token, l)
+		}
+		close(l.done)
+	}
+	select {
+	case <-l.done:
+		return l.userID, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (d API[R, W, PK]) currentUserID(ctx context.Context) (json.RawMessage, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/users/me",
+		map[string]string{
+			"fields": "id",
+		},
+		nil,
+	}
+	var respBody struct {
+		Data struct {
+			ID json.RawMessage `json:"id"`
+		} `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute current user request: %w", err)
+	}
+	return respBody.Data.ID, nil
+}
+
+// ownBody sets the owner field of an insert payload unless it's already set
+func (d API[R, W, PK]) ownBody(ctx context.Context, body any) (any, error) {
+	if d.Owner == nil {
+		return body, nil
+	}
+	userID, err := d.CurrentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return setBodyField(body, d.Owner.Field, userID, false)
+}
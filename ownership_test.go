@@ -0,0 +1,108 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnership(t *testing.T) {
+	var meCalls int
+	var bodies []map[string]any
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/users/me" {
+			meCalls++
+			assert.Equal(t, "id", r.URL.Query().Get("fields"))
+			_, _ = w.Write([]byte(`{"data":{"id":"8a2c"}}`))
+			return
+		}
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.Owner = &Ownership{Field: "owner"}
+	api.BearerToken = "t0k3n"
+	ctx := context.Background()
+
+	_, err := api.Insert(ctx, FruitW{Name: "kiwi"})
+	require.NoError(t, err)
+	_, err = api.Create(ctx, map[string]any{"name": "lime", "owner": "f00d"})
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "8a2c", bodies[0]["owner"])
+	assert.Equal(t, "f00d", bodies[1]["owner"])
+	assert.Equal(t, 1, meCalls)
+
+	api.BearerToken = "other"
+	_, err = api.Create(ctx, map[string]any{"name": "lime"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, meCalls)
+}
+
+func TestOwnershipPerToken(t *testing.T) {
+	var meCalls int32
+	release := make(chan struct{})
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/users/me" {
+			atomic.AddInt32(&meCalls, 1)
+			<-release
+			fmt.Fprintf(w, `{"data":{"id":%q}}`, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.Owner = &Ownership{Field: "owner"}
+
+	var wg sync.WaitGroup
+	ids := make([]string, 6)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := WithToken(context.Background(), fmt.Sprintf("user-%d", i%2))
+			id, err := api.CurrentUserID(ctx)
+			assert.NoError(t, err)
+			ids[i] = string(id)
+		}(i)
+	}
+	// lookups of different users run concurrently, the same user shares one
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&meCalls) == 2 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 2, atomic.LoadInt32(&meCalls))
+	for i, id := range ids {
+		assert.Equal(t, fmt.Sprintf(`"user-%d"`, i%2), id)
+	}
+
+	_, err := api.CurrentUserID(WithToken(context.Background(), "user-1"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&meCalls))
+}
+
+func TestOwnershipSessionCookies(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		cookie, err := r.Cookie("directus_session")
+		require.NoError(t, err)
+		fmt.Fprintf(w, `{"data":{"id":%q}}`, cookie.Value)
+	}))
+	api.Owner = &Ownership{Field: "owner"}
+
+	// sessions share the empty token, so they must not share a cached owner
+	for _, user := range []string{"a", "b"} {
+		ctx := withHeaders(context.Background(), map[string]string{"Cookie": "directus_session=" + user})
+		id, err := api.CurrentUserID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `"`+user+`"`, string(id))
+	}
+}
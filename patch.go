@@ -0,0 +1,108 @@
+package directusapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFieldNotAllowed is returned for patches changing fields which are not allowed
+var ErrFieldNotAllowed = errors.New("field is not allowed")
+
+// Content types of patch documents accepted by PatchPartials
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+// PatchPartials translates a patch document of the given content type to partials of Update,
+// e.g. of a request of a frontend. Only allowed fields can be changed, other fields fail with ErrFieldNotAllowed.
+func PatchPartials(contentType string, doc []byte, allowed ...string) (map[string]any, error) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(mediaType) {
+	case ContentTypeMergePatch:
+		return MergePatchPartials(doc, allowed...)
+	case ContentTypeJSONPatch:
+		return JSONPatchPartials(doc, allowed...)
+	}
+	return nil, fmt.Errorf("unsupported patch content type %q", contentType)
+}
+
+// MergePatchPartials translates an RFC 7386 JSON merge patch to partials of Update, null removes the value
+// of a field. Nested objects are sent as they are, so values of JSON fields are replaced instead of merged.
+// Only allowed fields can be changed, other fields fail with ErrFieldNotAllowed.
+func MergePatchPartials(doc []byte, allowed ...string) (map[string]any, error) {
+	var partials map[string]any
+	if err := decodePatch(doc, &partials); err != nil {
+		return nil, fmt.Errorf("decode merge patch: %w", err)
+	}
+	if partials == nil {
+		return nil, errors.New("merge patch has to be an object")
+	}
+	for f := range partials {
+		if err := allowedField(f, allowed); err != nil {
+			return nil, err
+		}
+	}
+	return partials, nil
+}
+
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// JSONPatchPartials translates RFC 6902 JSON patch operations add, replace and remove of top level fields
+// to partials of Update, remove sets the field to null. Other operations and nested paths aren't supported
+// because they need the current item. Only allowed fields can be changed, other fields fail with ErrFieldNotAllowed.
+func JSONPatchPartials(doc []byte, allowed ...string) (map[string]any, error) {
+	var ops []jsonPatchOperation
+	if err := json.Unmarshal(doc, &ops); err != nil {
+		return nil, fmt.Errorf("decode json patch: %w", err)
+	}
+	partials := make(map[string]any, len(ops))
+	for i, op := range ops {
+		if !strings.HasPrefix(op.Path, "/") || strings.Contains(op.Path[1:], "/") {
+			return nil, fmt.Errorf("operation %d: path %q is not a top level field", i, op.Path)
+		}
+		field := strings.NewReplacer("~1", "/", "~0", "~").Replace(op.Path[1:])
+		if err := allowedField(field, allowed); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		switch op.Op {
+		case "add", "replace":
+			if op.Value == nil {
+				return nil, fmt.Errorf("operation %d: %s without a value", i, op.Op)
+			}
+			var v any
+			if err := decodePatch(op.Value, &v); err != nil {
+				return nil, fmt.Errorf("operation %d: decode value: %w", i, err)
+			}
+			partials[field] = v
+		case "remove":
+			partials[field] = nil
+		default:
+			return nil, fmt.Errorf("operation %d: %q is not supported", i, op.Op)
+		}
+	}
+	return partials, nil
+}
+
+// decodePatch decodes numbers as json.Number, so big integers and decimals are sent unchanged
+func decodePatch(data []byte, dest any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(dest)
+}
+
+func allowedField(field string, allowed []string) error {
+	for _, a := range allowed {
+		if a == field {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrFieldNotAllowed, field)
+}
@@ -0,0 +1,43 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchPartials(t *testing.T) {
+	allowed := []string{"name", "weight", "a/b", "tags"}
+
+	partials, err := PatchPartials("application/merge-patch+json; charset=utf-8",
+		[]byte(`{"name":"kiwi","weight":12345678901234567890,"tags":null}`), allowed...)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "kiwi", "weight": json.Number("12345678901234567890"), "tags": nil}, partials)
+
+	partials, err = PatchPartials(ContentTypeJSONPatch, []byte(`[
+		{"op":"replace","path":"/name","value":"kiwi"},
+		{"op":"add","path":"/a~1b","value":{"x":1}},
+		{"op":"remove","path":"/tags"}
+	]`), allowed...)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "kiwi", "a/b": map[string]any{"x": json.Number("1")}, "tags": nil}, partials)
+
+	for name, tc := range map[string]struct {
+		contentType, doc string
+	}{
+		"merge not allowed":  {ContentTypeMergePatch, `{"id":1}`},
+		"merge not object":   {ContentTypeMergePatch, `[1]`},
+		"json not allowed":   {ContentTypeJSONPatch, `[{"op":"remove","path":"/status"}]`},
+		"json nested path":   {ContentTypeJSONPatch, `[{"op":"replace","path":"/name/0","value":"k"}]`},
+		"json unsupported":   {ContentTypeJSONPatch, `[{"op":"move","from":"/name","path":"/tags"}]`},
+		"json without value": {ContentTypeJSONPatch, `[{"op":"add","path":"/name"}]`},
+		"content type":       {"application/json", `{}`},
+	} {
+		_, err := PatchPartials(tc.contentType, []byte(tc.doc), allowed...)
+		assert.Error(t, err, name)
+	}
+	_, err = MergePatchPartials([]byte(`{"id":1}`), allowed...)
+	assert.ErrorIs(t, err, ErrFieldNotAllowed)
+}
@@ -0,0 +1,37 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// ItemHook transforms a decoded read item, e.g. normalizes urls, trims strings or computes derived fields.
+// Hooks run for items of all requests of the API, an error fails the request.
+type ItemHook[R any] func(ctx context.Context, item *R) error
+
+// postProcess applies PostProcess hooks to read items of a decoded response
+func (a *API[R, W, PK]) postProcess(ctx context.Context, dest any) error {
+	if len(a.PostProcess) == 0 {
+		return nil
+	}
+	switch env := dest.(type) {
+	case *itemEnvelope[R]:
+		return a.applyHooks(ctx, &env.Data)
+	case *itemsEnvelope[R]:
+		for i := range env.Data {
+			if err := a.applyHooks(ctx, &env.Data[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *API[R, W, PK]) applyHooks(ctx context.Context, item *R) error {
+	for _, hook := range a.PostProcess {
+		if err := hook(ctx, item); err != nil {
+			return fmt.Errorf("post-process item: %w", err)
+		}
+	}
+	return nil
+}
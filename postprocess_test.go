@@ -0,0 +1,63 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostProcess(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/items/fruits" {
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":" kiwi "},{"id":2,"name":"plum"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":" kiwi "}}`))
+	}))
+	api.PostProcess = []ItemHook[FruitR]{
+		func(ctx context.Context, f *FruitR) error {
+			f.Name = strings.TrimSpace(f.Name)
+			return nil
+		},
+		func(ctx context.Context, f *FruitR) error {
+			f.Name = strings.ToUpper(f.Name)
+			return nil
+		},
+	}
+
+	fruits, err := api.Items(context.Background(), None())
+	require.NoError(t, err)
+	assert.Equal(t, "KIWI", fruits[0].Name)
+	assert.Equal(t, "PLUM", fruits[1].Name)
+
+	fruit, err := api.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "KIWI", fruit.Name)
+
+	api.PostProcess = append(api.PostProcess, func(ctx context.Context, f *FruitR) error {
+		return errors.New("invalid fruit")
+	})
+	_, err = api.GetByID(context.Background(), 1)
+	assert.ErrorContains(t, err, "post-process item: invalid fruit")
+}
+
+func TestPostProcessItemsUpdatedSince(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"name":" kiwi ","created_on":"2022-05-05 11:00:00","modified_on":"2022-05-05 11:00:00"}]}`))
+	}))
+	api.PostProcess = []ItemHook[FruitR]{func(ctx context.Context, f *FruitR) error {
+		f.Name = strings.TrimSpace(f.Name)
+		return nil
+	}}
+
+	fruits, _, err := api.ItemsUpdatedSince(context.Background(), time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC), None())
+	require.NoError(t, err)
+	require.Len(t, fruits, 1)
+	assert.Equal(t, "kiwi", fruits[0].Name)
+}
@@ -0,0 +1,63 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UserPreferences are the Data Studio preferences of a user,
+// Theme is auto, light or dark. Empty fields are not changed by SetPreferences.
+type UserPreferences struct {
+	Language string `json:"language,omitempty"`
+	Theme    string `json:"theme,omitempty"`
+	LastPage string `json:"last_page,omitempty"`
+}
+
+// Preferences retrieves the preferences of the user of the current token
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#retrieve-the-current-user
+func (d API[R, W, PK]) Preferences(ctx context.Context) (UserPreferences, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/users/me",
+		map[string]string{
+			"fields": "language,theme,last_page",
+		},
+		nil,
+	}
+	var respBody struct {
+		Data UserPreferences `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return UserPreferences{}, fmt.Errorf("execute preferences request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// SetPreferences updates the preferences of the user of the current token
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#update-the-current-user
+func (d API[R, W, PK]) SetPreferences(ctx context.Context, p UserPreferences) (UserPreferences, error) {
+	req := request{
+		ctx,
+		http.MethodPatch,
+		d.baseURL() + "/users/me",
+		map[string]string{
+			"fields": "language,theme,last_page",
+		},
+		p,
+	}
+	var respBody struct {
+		Data UserPreferences `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return UserPreferences{}, fmt.Errorf("execute set preferences request: %w", err)
+	}
+	return respBody.Data, nil
+}
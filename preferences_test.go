@@ -0,0 +1,37 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferences(t *testing.T) {
+	prefs := map[string]any{"language": "en-US", "theme": "auto", "last_page": "/content"}
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/users/me", r.URL.Path)
+		assert.Equal(t, "language,theme,last_page", r.URL.Query().Get("fields"))
+		if r.Method == http.MethodPatch {
+			var changes map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&changes))
+			assert.Equal(t, map[string]any{"theme": "dark"}, changes)
+			for k, v := range changes {
+				prefs[k] = v
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": prefs})
+	}))
+	ctx := context.Background()
+
+	p, err := api.Preferences(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, UserPreferences{"en-US", "auto", "/content"}, p)
+
+	p, err = api.SetPreferences(ctx, UserPreferences{Theme: "dark"})
+	require.NoError(t, err)
+	assert.Equal(t, UserPreferences{"en-US", "dark", "/content"}, p)
+}
@@ -0,0 +1,76 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Problem is an RFC 7807 problem details body describing an error of the client,
+// Code is the Directus error code when Directus reported one
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+// NewProblem translates errors of the package to problem details for servers proxying Directus.
+// Client errors reported by Directus are passed through with their messages, failures of Directus itself
+// and of its authentication of the server are reported as 502 Bad Gateway. Other problems have only
+// a generic title, so internal urls and messages aren't exposed to end users, err should be logged instead.
+func NewProblem(err error) Problem {
+	p := Problem{Type: "about:blank", Status: problemStatus(err)}
+	p.Title = http.StatusText(p.Status)
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && len(respErr.Errors) > 0 && p.Status >= 400 && p.Status < 500 {
+		msgs := make([]string, len(respErr.Errors))
+		for i, e := range respErr.Errors {
+			msgs[i] = e.Message
+		}
+		p.Detail = strings.Join(msgs, "; ")
+		p.Code = respErr.Errors[0].Code
+	}
+	return p
+}
+
+func problemStatus(err error) int {
+	var (
+		respErr    *ResponseError
+		filterErr  *FilterValueError
+		payloadErr *PayloadSizeError
+	)
+	switch {
+	case errors.As(err, &respErr):
+		switch {
+		case respErr.StatusCode == http.StatusUnauthorized, respErr.StatusCode >= 500 && respErr.StatusCode != http.StatusServiceUnavailable:
+			return http.StatusBadGateway
+		case respErr.StatusCode == http.StatusTooManyRequests:
+			return http.StatusServiceUnavailable
+		}
+		return respErr.StatusCode
+	case errors.As(err, &filterErr):
+		return http.StatusBadRequest
+	case errors.As(err, &payloadErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrOutOfScope):
+		return http.StatusNotFound
+	case errors.Is(err, ErrClosed), errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteProblem responds with problem details of err, see NewProblem
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := NewProblem(err)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
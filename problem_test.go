@@ -0,0 +1,61 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemStatus(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+	}{
+		"forbidden":    {newResponseError(403, "403 Forbidden", nil), http.StatusForbidden},
+		"unauthorized": {newResponseError(401, "401 Unauthorized", nil), http.StatusBadGateway},
+		"server error": {newResponseError(500, "500 Internal Server Error", nil), http.StatusBadGateway},
+		"rate limited": {newResponseError(429, "429 Too Many Requests", nil), http.StatusServiceUnavailable},
+		"filter":       {&FilterValueError{"id", "integer", "x"}, http.StatusBadRequest},
+		"payload":      {&PayloadSizeError{2, 1}, http.StatusRequestEntityTooLarge},
+		"scope":        {fmt.Errorf("update: %w", ErrOutOfScope), http.StatusNotFound},
+		"deadline":     {context.DeadlineExceeded, http.StatusGatewayTimeout},
+		"other":        {errors.New("boom"), http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.status, NewProblem(tt.err).Status)
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := &OperationError{Err: newResponseError(400, "400 Bad Request", []byte(`{"errors":[{"message":"name is required","extensions":{"code":"INVALID_PAYLOAD"}}]}`))}
+	WriteProblem(rec, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&p))
+	assert.Equal(t, Problem{"about:blank", "Bad Request", 400, "name is required", "INVALID_PAYLOAD"}, p)
+}
+
+func TestProblemHidesInternals(t *testing.T) {
+	internal := newResponseError(500, "500 Internal Server Error", []byte(`{"errors":[{"message":"relation \"fruits\" does not exist","extensions":{"code":"INTERNAL"}}]}`))
+	for _, err := range []error{
+		&OperationError{Method: "GET", URL: "http://directus.internal/items/fruits", Err: internal},
+		errors.New("dial tcp 10.0.0.7:8055: connection refused"),
+	} {
+		p := NewProblem(err)
+		assert.Empty(t, p.Detail)
+		assert.Empty(t, p.Code)
+		assert.Equal(t, http.StatusText(p.Status), p.Title)
+	}
+}
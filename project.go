@@ -0,0 +1,357 @@
+package directusapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/antoniobuconjic/directusapi/anonymize"
+)
+
+// archive layout of DumpProject
+const (
+	archiveCollectionsDir = "collections/"
+	archiveFilesDir       = "files/"
+	archiveFilesMeta      = "files.json"
+	archiveFolders        = "folders.json"
+)
+
+// DumpOptions controls what DumpProject exports
+type DumpOptions struct {
+	// Files exports files including their content, it's supported only by v9
+	Files bool
+	// Transform replaces values of fields before they are written to the archive,
+	// keys are collection.field or *.field for a field of all collections, e.g. directus_files.title,
+	// see package anonymize
+	Transform map[string]anonymize.Transformer
+}
+
+// DumpProject exports items of all non-system collections of the instance to w as a gzipped tar archive,
+// every collection is stored as a JSON array in collections/<name>.json. With Files the folders are stored
+// in folders.json, the metadata of files in files.json and their content in files/<id>. It's meant for lightweight content backups
+// and copies between environments, the schema isn't exported, see SchemaSnapshot.
+func (d API[R, W, PK]) DumpProject(ctx context.Context, w io.Writer, opts DumpOptions) error {
+	if opts.Files && d.Version == V8 {
+		return errors.New("dump of files is supported only by v9")
+	}
+	collections, err := d.userCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if opts.Files {
+		// items may reference files, so they are restored first
+		if err := d.dumpFiles(ctx, tw, opts.Transform); err != nil {
+			return err
+		}
+	}
+	for _, c := range collections {
+		items, err := d.rawItems(ctx, fmt.Sprintf("%s/items/%s", d.baseURL(), c))
+		if err != nil {
+			return fmt.Errorf("dump %s: %w", c, err)
+		}
+		if items, err = anonymize.Items(items, anonymize.ForCollection(opts.Transform, c)); err != nil {
+			return fmt.Errorf("transform %s: %w", c, err)
+		}
+		if err := writeArchiveJSON(tw, archiveCollectionsDir+c+".json", items); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close archive: %w", err)
+	}
+	return gz.Close()
+}
+
+// RestoreProject creates folders, files and items of an archive written by DumpProject.
+// The target instance needs the same schema, items keep their primary keys. Folders and files
+// are restored before items which may reference them, collections are restored in the order of the archive.
+func (d API[R, W, PK]) RestoreProject(ctx context.Context, r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	var files, folders []json.RawMessage
+	contents := map[string][]byte{}
+	type archivedCollection struct {
+		name string
+		data []byte
+	}
+	var collections []archivedCollection
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		switch {
+		case hdr.Name == archiveFilesMeta:
+			if err := json.Unmarshal(data, &files); err != nil {
+				return fmt.Errorf("decode %s: %w", hdr.Name, err)
+			}
+		case hdr.Name == archiveFolders:
+			if err := json.Unmarshal(data, &folders); err != nil {
+				return fmt.Errorf("decode %s: %w", hdr.Name, err)
+			}
+		case strings.HasPrefix(hdr.Name, archiveFilesDir):
+			contents[strings.TrimPrefix(hdr.Name, archiveFilesDir)] = data
+		case strings.HasPrefix(hdr.Name, archiveCollectionsDir):
+			c := strings.TrimSuffix(path.Base(hdr.Name), ".json")
+			collections = append(collections, archivedCollection{c, data})
+		}
+	}
+	if err := d.restoreFolders(ctx, folders); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := d.restoreFile(ctx, f, contents); err != nil {
+			return err
+		}
+	}
+	for _, c := range collections {
+		if err := d.restoreItems(ctx, c.name, c.data); err != nil {
+			return fmt.Errorf("restore %s: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// userCollections lists collections which are not system collections nor folders
+func (d API[R, W, PK]) userCollections(ctx context.Context) ([]string, error) {
+	var respBody struct {
+		Data []struct {
+			Collection string `json:"collection"`
+			// Schema is null for folders of v9
+			Schema json.RawMessage `json:"schema"`
+		} `json:"data"`
+	}
+	err := d.getMetadata(ctx, d.baseURL()+"/collections", &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute collections request: %w", err)
+	}
+	var out []string
+	for _, c := range respBody.Data {
+		if strings.HasPrefix(c.Collection, systemPrefix) || (d.Version == V9 && string(c.Schema) == "null") {
+			continue
+		}
+		out = append(out, c.Collection)
+	}
+	return out, nil
+}
+
+// rawItems retrieves all items of u with all their fields
+func (d API[R, W, PK]) rawItems(ctx context.Context, u string) ([]json.RawMessage, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		map[string]string{
+			"fields": "*",
+			"limit":  "-1",
+		},
+		nil,
+	}
+	var respBody struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute items request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+func (d API[R, W, PK]) dumpFiles(ctx context.Context, tw *tar.Writer, transform map[string]anonymize.Transformer) error {
+	folders, err := d.rawItems(ctx, d.baseURL()+"/folders")
+	if err != nil {
+		return fmt.Errorf("dump folders: %w", err)
+	}
+	if folders, err = anonymize.Items(folders, anonymize.ForCollection(transform, CollectionFolders)); err != nil {
+		return fmt.Errorf("transform folders: %w", err)
+	}
+	if err := writeArchiveJSON(tw, archiveFolders, folders); err != nil {
+		return err
+	}
+
+	files, err := d.rawItems(ctx, d.baseURL()+"/files")
+	if err != nil {
+		return fmt.Errorf("dump files: %w", err)
+	}
+	meta, err := anonymize.Items(files, anonymize.ForCollection(transform, CollectionFiles))
+	if err != nil {
+		return fmt.Errorf("transform files: %w", err)
+	}
+	if err := writeArchiveJSON(tw, archiveFilesMeta, meta); err != nil {
+		return err
+	}
+	for _, f := range files {
+		var meta struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(f, &meta); err != nil {
+			return fmt.Errorf("decode file: %w", err)
+		}
+		var content bytes.Buffer
+		req := request{
+			ctx,
+			http.MethodGet,
+			d.baseURL() + "/assets/" + meta.ID,
+			nil,
+			nil,
+		}
+		if err := d.executeRequest(req, http.StatusOK, &content); err != nil {
+			return fmt.Errorf("execute asset request of %s: %w", meta.ID, err)
+		}
+		if err := writeArchiveFile(tw, archiveFilesDir+meta.ID, content.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d API[R, W, PK]) restoreItems(ctx context.Context, collection string, data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("decode items: %w", err)
+	}
+	encoded := make([][]byte, len(items))
+	for i, item := range items {
+		encoded[i] = item
+	}
+	d.loadServerInfo(ctx)
+	maxBytes := d.maxPayloadSize()
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxPayloadSize
+	}
+	for _, c := range splitChunks(encoded, defaultChunkSize, maxBytes) {
+		req := request{
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("%s/items/%s", d.baseURL(), collection),
+			nil,
+			c.body,
+		}
+		if err := d.executeRequest(req, http.StatusOK, nil); err != nil {
+			return fmt.Errorf("execute create items request: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFolders creates folders with their ids, parents are created before their subfolders
+func (d API[R, W, PK]) restoreFolders(ctx context.Context, folders []json.RawMessage) error {
+	type folder struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Parent *string `json:"parent"`
+	}
+	pending := make([]folder, len(folders))
+	for i, f := range folders {
+		if err := json.Unmarshal(f, &pending[i]); err != nil {
+			return fmt.Errorf("decode folder: %w", err)
+		}
+	}
+	created := map[string]bool{}
+	for len(pending) > 0 {
+		var next []folder
+		for _, f := range pending {
+			if f.Parent != nil && !created[*f.Parent] {
+				next = append(next, f)
+				continue
+			}
+			req := request{
+				ctx,
+				http.MethodPost,
+				d.baseURL() + "/folders",
+				nil,
+				f,
+			}
+			if err := d.executeRequest(req, http.StatusOK, nil); err != nil {
+				return fmt.Errorf("execute create folder request of %s: %w", f.ID, err)
+			}
+			created[f.ID] = true
+		}
+		if len(next) == len(pending) {
+			return fmt.Errorf("restore folders: parent %s of folder %s is missing", *next[0].Parent, next[0].ID)
+		}
+		pending = next
+	}
+	return nil
+}
+
+// restoreFile uploads a file with its metadata, fields are sent before the file as Directus requires
+func (d API[R, W, PK]) restoreFile(ctx context.Context, meta json.RawMessage, contents map[string][]byte) error {
+	var fields map[string]any
+	if err := json.Unmarshal(meta, &fields); err != nil {
+		return fmt.Errorf("decode file: %w", err)
+	}
+	id := fmt.Sprint(fields["id"])
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, k := range []string{"id", "title", "description", "folder", "type"} {
+		if v, ok := fields[k].(string); ok {
+			if err := mw.WriteField(k, v); err != nil {
+				return fmt.Errorf("write file field: %w", err)
+			}
+		}
+	}
+	name, _ := fields["filename_download"].(string)
+	fw, err := mw.CreateFormFile("file", name)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	if _, err := fw.Write(contents[id]); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/files",
+		nil,
+		rawBody{mw.FormDataContentType(), body.Bytes()},
+	}
+	if err := d.executeRequest(req, http.StatusOK, nil); err != nil {
+		return fmt.Errorf("execute upload request of %s: %w", id, err)
+	}
+	return nil
+}
+
+func writeArchiveJSON(tw *tar.Writer, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+	return writeArchiveFile(tw, name, data)
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data))}); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpRestoreProject(t *testing.T) {
+	created := map[string]string{}
+	var uploaded []byte
+	var restored []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			restored = append(restored, r.URL.Path)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /_/collections":
+			_, _ = w.Write([]byte(`{"data":[{"collection":"directus_users","schema":{}},{"collection":"shop","schema":null},{"collection":"fruits","schema":{}}]}`))
+		case "GET /_/items/fruits":
+			assert.Equal(t, "-1", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi","image":"8a2c"},{"id":2,"name":"lime"}]}`))
+		case "GET /_/folders":
+			_, _ = w.Write([]byte(`{"data":[{"id":"f2","name":"Logos","parent":"f1"},{"id":"f1","name":"Brand","parent":null}]}`))
+		case "GET /_/files":
+			_, _ = w.Write([]byte(`{"data":[{"id":"8a2c","title":"Logo","folder":"f2","filename_download":"logo.png"}]}`))
+		case "POST /_/folders":
+			body, _ := io.ReadAll(r.Body)
+			created["folders"] += string(body)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case "GET /_/assets/8a2c":
+			_, _ = w.Write([]byte("png"))
+		case "POST /_/items/fruits":
+			body, _ := io.ReadAll(r.Body)
+			created["fruits"] = string(body)
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "POST /_/files":
+			assert.Equal(t, "Logo", r.FormValue("title"))
+			assert.Equal(t, "8a2c", r.FormValue("id"))
+			assert.Equal(t, "f2", r.FormValue("folder"))
+			f, hdr, err := r.FormFile("file")
+			require.NoError(t, err)
+			assert.Equal(t, "logo.png", hdr.Filename)
+			uploaded, _ = io.ReadAll(f)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	api.Version = V9
+	ctx := context.Background()
+
+	var archive bytes.Buffer
+	require.NoError(t, api.DumpProject(ctx, &archive, DumpOptions{Files: true}))
+	require.NoError(t, api.RestoreProject(ctx, &archive))
+	assert.Equal(t, map[string]string{
+		"fruits":  `[{"id":1,"name":"kiwi","image":"8a2c"},{"id":2,"name":"lime"}]`,
+		"folders": `{"id":"f1","name":"Brand","parent":null}{"id":"f2","name":"Logos","parent":"f1"}`,
+	}, created)
+	assert.Equal(t, "png", string(uploaded))
+	// items referencing files are restored after the files and files after their folders
+	assert.Equal(t, []string{"/_/folders", "/_/folders", "/_/files", "/_/items/fruits"}, restored)
+
+	api.Version = V8
+	assert.Error(t, api.DumpProject(ctx, io.Discard, DumpOptions{Files: true}))
+}
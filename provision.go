@@ -0,0 +1,237 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// EnsureRole returns the role with the given name and creates it when it doesn't exist,
+// so bootstrap scripts can run repeatedly. Permissions of perms which the role lacks,
+// matched by collection and action, are added to it, existing permissions aren't changed.
+// Roles of perms are ignored. It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/roles.html
+// https://docs.directus.io/reference/system/permissions.html
+func (d API[R, W, PK]) EnsureRole(ctx context.Context, name string, perms []PermissionW) (Role, error) {
+	if d.Version == V8 {
+		return Role{}, errors.New("ensure role is supported only by v9")
+	}
+	var roles struct {
+		Data []Role `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, "/roles", Eq("name", name).Limit(1), nil, &roles); err != nil {
+		return Role{}, fmt.Errorf("execute roles request: %w", err)
+	}
+	var role Role
+	if len(roles.Data) > 0 {
+		role = roles.Data[0]
+	} else {
+		var created itemEnvelope[Role]
+		if err := d.provisionRequest(ctx, http.MethodPost, "/roles", None(), RoleW{Name: name}, &created); err != nil {
+			return Role{}, fmt.Errorf("execute create role request: %w", err)
+		}
+		role = created.Data
+	}
+
+	var existing struct {
+		Data []Permission `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, "/permissions", Eq("role", role.ID).Limit(AllItems), nil, &existing); err != nil {
+		return Role{}, fmt.Errorf("execute permissions request: %w", err)
+	}
+	granted := map[string]bool{}
+	for _, p := range existing.Data {
+		granted[p.Collection+"/"+p.Action] = true
+	}
+	missing := []PermissionW{}
+	for _, p := range perms {
+		if key := p.Collection + "/" + p.Action; !granted[key] {
+			granted[key] = true
+			p.Role = SetOptional(role.ID)
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		if err := d.provisionRequest(ctx, http.MethodPost, "/permissions", None(), missing, nil); err != nil {
+			return Role{}, fmt.Errorf("execute create permissions request: %w", err)
+		}
+	}
+	return role, nil
+}
+
+// EnsureUser returns the user with the given email and creates it with the role when it doesn't exist,
+// the role of an existing user is changed to roleID. Created users have no password,
+// it's set by InviteUser or a password reset. It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html
+func (d API[R, W, PK]) EnsureUser(ctx context.Context, email, roleID string) (User, error) {
+	if d.Version == V8 {
+		return User{}, errors.New("ensure user is supported only by v9")
+	}
+	var users struct {
+		Data []User `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, "/users", Eq("email", email).Limit(1), nil, &users); err != nil {
+		return User{}, fmt.Errorf("execute users request: %w", err)
+	}
+	if len(users.Data) > 0 && users.Data[0].Role == roleID {
+		return users.Data[0], nil
+	}
+	var user itemEnvelope[User]
+	if len(users.Data) > 0 {
+		path := "/users/" + url.PathEscape(users.Data[0].ID)
+		if err := d.provisionRequest(ctx, http.MethodPatch, path, None(), UserW{Role: roleID}, &user); err != nil {
+			return User{}, fmt.Errorf("execute update user request: %w", err)
+		}
+		return user.Data, nil
+	}
+	if err := d.provisionRequest(ctx, http.MethodPost, "/users", None(), UserW{Email: email, Role: roleID}, &user); err != nil {
+		return User{}, fmt.Errorf("execute create user request: %w", err)
+	}
+	return user.Data, nil
+}
+
+// EnsureCollectionExists creates the collection of the API with fields of model, a struct like the write model,
+// when it doesn't exist. Fields of model missing in an existing collection are added, existing fields
+// aren't changed. Field types are derived from Go types, e.g. int is an integer and Time a timestamp,
+// other structs, maps and slices are JSON fields. The id field is the primary key, it's auto incremented
+// for integers and a generated uuid for strings, the server adds an integer id to models without it.
+// Relations aren't created. It's supported only by v9.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/collections.html#create-a-collection
+// https://docs.directus.io/reference/system/fields.html#create-a-field-in-a-collection
+func (d API[R, W, PK]) EnsureCollectionExists(ctx context.Context, model any) error {
+	if d.Version == V8 {
+		return errors.New("ensure collection is supported only by v9")
+	}
+	fields, err := modelFields(reflect.TypeOf(model))
+	if err != nil {
+		return err
+	}
+	collectionPath := "/collections/" + url.PathEscape(d.CollectionName)
+	err = d.provisionRequest(ctx, http.MethodGet, collectionPath, None(), nil, nil)
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && (respErr.StatusCode == http.StatusNotFound || respErr.StatusCode == http.StatusForbidden) {
+		// missing collections are forbidden for non-admin users
+		body := map[string]any{
+			"collection": d.CollectionName,
+			"schema":     map[string]any{},
+			"fields":     fields,
+		}
+		if err := d.provisionRequest(ctx, http.MethodPost, "/collections", None(), body, nil); err != nil {
+			return fmt.Errorf("execute create collection request: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("execute collection request: %w", err)
+	}
+
+	fieldsPath := "/fields/" + url.PathEscape(d.CollectionName)
+	var existing struct {
+		Data []FieldSchema `json:"data"`
+	}
+	if err := d.provisionRequest(ctx, http.MethodGet, fieldsPath, None(), nil, &existing); err != nil {
+		return fmt.Errorf("execute fields request: %w", err)
+	}
+	found := map[string]bool{}
+	for _, f := range existing.Data {
+		found[f.Field] = true
+	}
+	for _, f := range fields {
+		if found[f.Field] {
+			continue
+		}
+		if err := d.provisionRequest(ctx, http.MethodPost, fieldsPath, None(), f, nil); err != nil {
+			return fmt.Errorf("execute create field %s request: %w", f.Field, err)
+		}
+	}
+	return nil
+}
+
+func (d API[R, W, PK]) provisionRequest(ctx context.Context, method, path string, q Query, body, dest any) error {
+	qv := q.asKeyValueAt(d.Version, d.clock().Now())
+	if method != http.MethodGet {
+		qv = nil
+	}
+	req := request{
+		ctx,
+		method,
+		d.baseURL() + path,
+		qv,
+		body,
+	}
+	expected := http.StatusOK
+	if method == http.MethodDelete {
+		expected = http.StatusNoContent
+	}
+	return d.executeRequest(req, expected, dest)
+}
+
+// modelField is a field of a created collection
+type modelField struct {
+	Field  string         `json:"field"`
+	Type   string         `json:"type"`
+	Schema map[string]any `json:"schema,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+// modelFields returns fields of a collection created for struct t, relations are left out
+func modelFields(t reflect.Type) ([]modelField, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model has to be a struct, got %v", t)
+	}
+	var fields []modelField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, skip := jsonName(f)
+		if skip || f.Type.Implements(relationType) {
+			continue
+		}
+		field := modelField{Field: name, Type: fieldType(f.Type)}
+		if name == "id" {
+			field.Schema = map[string]any{"is_primary_key": true}
+			field.Meta = map[string]any{"hidden": true, "readonly": true}
+			switch field.Type {
+			case "integer", "bigInteger":
+				field.Schema["has_auto_increment"] = true
+			case "string":
+				field.Type = "uuid"
+				field.Meta["special"] = []string{"uuid"}
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// fieldType returns a Directus type of a field of Go type t
+func fieldType(t reflect.Type) string {
+	if t.ConvertibleTo(reflect.TypeOf(Time{})) {
+		return "timestamp"
+	}
+	if t.Implements(reflect.TypeOf(new(isOpt)).Elem()) && !t.Implements(relationType) {
+		// the value of an optional is its first field
+		return fieldType(t.Field(0).Type)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "integer"
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigInteger"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	}
+	return "json"
+}
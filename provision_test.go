@@ -0,0 +1,121 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureRoleAndUser(t *testing.T) {
+	var created []string
+	var bodies []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodGet {
+			created = append(created, r.Method+" "+r.URL.Path)
+			bodies = append(bodies, string(b))
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /_/roles":
+			assert.Equal(t, "editors", r.URL.Query().Get("filter[name][_eq]"))
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "POST /_/roles":
+			_, _ = w.Write([]byte(`{"data":{"id":"r1","name":"editors"}}`))
+		case "GET /_/permissions":
+			assert.Equal(t, "r1", r.URL.Query().Get("filter[role][_eq]"))
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"role":"r1","collection":"articles","action":"read"}]}`))
+		case "POST /_/permissions":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "GET /_/users":
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","email":"jane@example.com","role":"r0"}]}`))
+		case "PATCH /_/users/u1":
+			_, _ = w.Write([]byte(`{"data":{"id":"u1","email":"jane@example.com","role":"r1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	api.Version = V9
+
+	role, err := api.EnsureRole(context.Background(), "editors", []PermissionW{
+		{Collection: "articles", Action: "read"},
+		{Collection: "articles", Action: "update", Fields: []string{"title"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "r1", role.ID)
+	require.Equal(t, []string{"POST /_/roles", "POST /_/permissions"}, created)
+	assert.JSONEq(t, `[{"role":"r1","collection":"articles","action":"update","fields":["title"]}]`, bodies[1])
+
+	user, err := api.EnsureUser(context.Background(), "jane@example.com", role.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "r1", user.Role)
+	assert.Equal(t, "PATCH /_/users/u1", created[2])
+	assert.JSONEq(t, `{"role":"r1"}`, bodies[2])
+
+	api.Version = V8
+	_, err = api.EnsureRole(context.Background(), "editors", nil)
+	assert.Error(t, err)
+}
+
+type provisionedW struct {
+	ID        int               `json:"id"`
+	Title     string            `json:"title"`
+	Views     int64             `json:"views"`
+	Price     Optional[float64] `json:"price"`
+	Published Time              `json:"published_at"`
+	Meta      map[string]any    `json:"meta"`
+	Tags      Relation[int]     `json:"tags"`
+	Internal  string            `json:"-"`
+}
+
+func TestEnsureCollectionExists(t *testing.T) {
+	exists := false
+	var bodies []map[string]any
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /_/collections/fruits":
+			if !exists {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"collection":"fruits"}}`))
+		case "GET /_/fields/fruits":
+			_, _ = w.Write([]byte(`{"data":[{"field":"id","type":"integer"},{"field":"title","type":"string"}]}`))
+		case "POST /_/collections", "POST /_/fields/fruits":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			bodies = append(bodies, body)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	api.Version = V9
+
+	require.NoError(t, api.EnsureCollectionExists(context.Background(), provisionedW{}))
+	require.Len(t, bodies, 1)
+	b, _ := json.Marshal(bodies[0])
+	assert.JSONEq(t, `{"collection":"fruits","schema":{},"fields":[
+		{"field":"id","type":"integer","schema":{"is_primary_key":true,"has_auto_increment":true},"meta":{"hidden":true,"readonly":true}},
+		{"field":"title","type":"string"},
+		{"field":"views","type":"bigInteger"},
+		{"field":"price","type":"float"},
+		{"field":"published_at","type":"timestamp"},
+		{"field":"meta","type":"json"}
+	]}`, string(b))
+
+	exists = true
+	bodies = nil
+	require.NoError(t, api.EnsureCollectionExists(context.Background(), provisionedW{}))
+	fields := []any{}
+	for _, b := range bodies {
+		fields = append(fields, b["field"])
+	}
+	assert.Equal(t, []any{"views", "price", "published_at", "meta"}, fields)
+
+	assert.Error(t, api.EnsureCollectionExists(context.Background(), "fruits"))
+}
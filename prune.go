@@ -0,0 +1,158 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PruneOptions controls how Prune deletes items
+type PruneOptions[R any] struct {
+	// BatchSize is a number of items deleted by one request, defaults to 100
+	BatchSize int
+	// DryRun only counts and archives matching items without deleting them
+	DryRun bool
+	// Interval is a pause between batches, so retention jobs don't overload the server
+	Interval time.Duration
+	// PrimaryKey is the primary key field of the collection, defaults to id
+	PrimaryKey string
+	// Archive is optional, it receives every batch before it's deleted,
+	// a returned error stops pruning before the batch is deleted
+	Archive func(items []R) error
+	// Progress is optional, it's called after every batch with the number of items pruned so far
+	Progress func(pruned int)
+}
+
+// Prune deletes items matching q in batches, e.g. for data retention jobs.
+// It returns the number of deleted items, matching items in DryRun.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/items.html#delete-multiple-items
+// https://v8.docs.directus.io/api/items.html#delete-items
+func (d API[R, W, PK]) Prune(ctx context.Context, q Query, opts PruneOptions[R]) (int, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultChunkSize
+	}
+	pkField := opts.PrimaryKey
+	if pkField == "" {
+		pkField = "id"
+	}
+	q, err := d.scopeQuery(ctx, q.withDefaults(d.DefaultQuery))
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for {
+		page := q.Limit(batchSize)
+		if opts.DryRun {
+			// nothing is deleted, so the batches have to be paged
+			page = page.Offset(pruned)
+		}
+		items, ids, err := d.pruneBatch(ctx, page, pkField)
+		if err != nil {
+			return pruned, err
+		}
+		if len(items) == 0 {
+			return pruned, nil
+		}
+		if opts.Archive != nil {
+			if err := opts.Archive(items); err != nil {
+				return pruned, fmt.Errorf("archive: %w", err)
+			}
+		}
+		if !opts.DryRun {
+			if err := d.deleteMany(ctx, ids); err != nil {
+				return pruned, err
+			}
+		}
+		pruned += len(items)
+		if opts.Progress != nil {
+			opts.Progress(pruned)
+		}
+		if len(items) < batchSize {
+			return pruned, nil
+		}
+		if opts.Interval > 0 {
+			if err := d.sleep(ctx, opts.Interval); err != nil {
+				return pruned, err
+			}
+		}
+	}
+}
+
+// pruneBatch retrieves a batch of items together with their primary keys
+func (d API[R, W, PK]) pruneBatch(ctx context.Context, q Query, pkField string) ([]R, []json.RawMessage, error) {
+	if err := d.supported(q); err != nil {
+		return nil, nil, err
+	}
+	u, qv := d.itemsRequestParams(q)
+	qv["fields"] = strings.Join(append(d.jsonFieldsR(), pkField), ",")
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	var respBody json.RawMessage
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execute prune items request: %w", err)
+	}
+
+	var items itemsEnvelope[R]
+	if err := json.Unmarshal(respBody, &items); err != nil {
+		return nil, nil, fmt.Errorf("decoding json response: %w", err)
+	}
+	// the envelope is decoded here, so hooks aren't applied by executeRequest
+	if err := d.postProcess(ctx, &items); err != nil {
+		return nil, nil, err
+	}
+	var keys struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &keys); err != nil {
+		return nil, nil, fmt.Errorf("decoding json response: %w", err)
+	}
+	ids := make([]json.RawMessage, len(keys.Data))
+	for i, k := range keys.Data {
+		if ids[i] = k[pkField]; ids[i] == nil {
+			return nil, nil, fmt.Errorf("item has no primary key %s", pkField)
+		}
+	}
+	return items.Data, ids, nil
+}
+
+// deleteMany deletes items by their primary keys in one request
+func (d API[R, W, PK]) deleteMany(ctx context.Context, ids []json.RawMessage) error {
+	u := d.itemsURL()
+	var body any = ids
+	if d.Version == V8 {
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			key, err := rawString(id)
+			if err != nil {
+				return fmt.Errorf("primary key: %w", err)
+			}
+			keys[i] = key
+		}
+		u += "/" + strings.Join(keys, ",")
+		body = nil
+	}
+	req := request{
+		ctx,
+		http.MethodDelete,
+		u,
+		nil,
+		body,
+	}
+	if err := d.executeRequest(req, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("execute delete many request: %w", err)
+	}
+	return nil
+}
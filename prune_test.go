@@ -0,0 +1,105 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPruneServer(t *testing.T, n int) (API[FruitR, FruitW, int], func() []int) {
+	var mu sync.Mutex
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "expired", r.URL.Query().Get("filter[status][_eq]"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			var page []map[string]int
+			for i := offset; i < len(ids) && i < offset+limit; i++ {
+				page = append(page, map[string]int{"id": ids[i]})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": page})
+		case http.MethodDelete:
+			var del []int
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&del))
+			kept := ids[:0]
+			for _, id := range ids {
+				if !containsInt(del, id) {
+					kept = append(kept, id)
+				}
+			}
+			ids = kept
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	api.Version = V9
+	return api, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int{}, ids...)
+	}
+}
+
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPrune(t *testing.T) {
+	api, remaining := newPruneServer(t, 5)
+	var archived []int
+	var progress []int
+	n, err := api.Prune(context.Background(), Eq("status", "expired"), PruneOptions[FruitR]{
+		BatchSize: 2,
+		Archive: func(items []FruitR) error {
+			for _, i := range items {
+				archived = append(archived, i.ID)
+			}
+			return nil
+		},
+		Progress: func(pruned int) {
+			progress = append(progress, pruned)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, archived)
+	assert.Equal(t, []int{2, 4, 5}, progress)
+	assert.Empty(t, remaining())
+}
+
+func TestPruneDryRun(t *testing.T) {
+	api, remaining := newPruneServer(t, 5)
+	n, err := api.Prune(context.Background(), Eq("status", "expired"), PruneOptions[FruitR]{
+		BatchSize: 2,
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Len(t, remaining(), 5)
+
+	_, err = api.Prune(context.Background(), Eq("status", "expired"), PruneOptions[FruitR]{
+		Archive: func([]FruitR) error {
+			return fmt.Errorf("disk full")
+		},
+	})
+	assert.ErrorContains(t, err, "disk full")
+	assert.Len(t, remaining(), 5)
+}
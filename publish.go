@@ -0,0 +1,133 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Live returns a query matching items whose publish window contains
+// the current server time. Empty publishField or unpublishField
+// means the window is open on that side.
+func Live(publishField, unpublishField string) Query {
+	return None().Live(publishField, unpublishField)
+}
+
+// Live filters items whose publish window contains the current server time.
+// Items with null publishField are live right away,
+// items with null unpublishField are never taken down.
+//
+// Directus v8 is not supported, its filters can't express an or group of a single field,
+// so v8 APIs reject queries built with Live.
+func (q Query) Live(publishField, unpublishField string) Query {
+	if publishField != "" {
+		q = q.Or(Null(publishField), Lte(publishField, Now))
+	}
+	if unpublishField != "" {
+		q = q.Or(Null(unpublishField), Gt(unpublishField, Now))
+	}
+	return q
+}
+
+type PublishEventType uint8
+
+const (
+	// Published is emitted when an item entered its publish window
+	Published PublishEventType = iota
+	// Unpublished is emitted when an item left its publish window
+	// or it was removed
+	Unpublished
+)
+
+// PublishEvent is emitted by WatchPublish when an item crosses its publish window
+type PublishEvent[R any] struct {
+	Type PublishEventType
+	Item R
+}
+
+// WatchPublish polls the collection with q every interval and emits an event
+// for every item which appeared in or disappeared from the result set since
+// the previous poll. The first poll is used as a baseline and emits no events.
+// id extracts the primary key of an item.
+//
+// q is usually built with Live, so v8 isn't supported. Queries which can't be sent to the server
+// are rejected right away, poll errors are sent to errs if it's not nil and the poll is retried
+// in the next interval. Returned channel is closed once ctx is done or the client is closed.
+func (d API[R, W, PK]) WatchPublish(ctx context.Context, q Query, interval time.Duration, id func(R) PK, errs chan<- error) (<-chan PublishEvent[R], error) {
+	full := q.withDefaults(d.DefaultQuery)
+	if err := full.Validate(); err != nil {
+		return nil, fmt.Errorf("watch publish: %w", err)
+	}
+	if err := d.supported(full); err != nil {
+		return nil, fmt.Errorf("watch publish: %w", err)
+	}
+	ctx, done, err := d.Lifecycle.background(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan PublishEvent[R])
+	go func() {
+		defer done()
+		defer close(events)
+		timer := d.clock().NewTimer(interval)
+		defer timer.Stop()
+
+		var live map[PK]R
+		for {
+			items, err := d.Items(ctx, q)
+			if err != nil {
+				if errs != nil && ctx.Err() == nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+					}
+				}
+			} else {
+				current := make(map[PK]R, len(items))
+				for _, item := range items {
+					current[id(item)] = item
+				}
+				if live != nil && !emitPublishEvents(ctx, events, live, current) {
+					return
+				}
+				live = current
+			}
+
+			select {
+			case <-timer.C():
+				timer.Reset(interval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// emitPublishEvents sends the difference of prev and current to events,
+// it returns false when ctx is done
+func emitPublishEvents[R any, PK PrimaryKey](ctx context.Context, events chan<- PublishEvent[R], prev, current map[PK]R) bool {
+	send := func(e PublishEvent[R]) bool {
+		select {
+		case events <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for k, item := range current {
+		if _, ok := prev[k]; !ok {
+			if !send(PublishEvent[R]{Published, item}) {
+				return false
+			}
+		}
+	}
+	for k, item := range prev {
+		if _, ok := current[k]; !ok {
+			if !send(PublishEvent[R]{Unpublished, item}) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveQuery(t *testing.T) {
+	q := Live("publish_at", "unpublish_at").Eq("status", "published")
+
+	assert.Equal(t, map[string]string{
+		"limit":               "-1",
+		"filter[status][_eq]": "published",
+		"filter[_and][0][_or][0][publish_at][_null]":   "true",
+		"filter[_and][0][_or][1][publish_at][_lte]":    "$NOW",
+		"filter[_and][1][_or][0][unpublish_at][_null]": "true",
+		"filter[_and][1][_or][1][unpublish_at][_gt]":   "$NOW",
+	}, q.asKeyValue(V9))
+
+	// v8 would or all the filters together
+	assert.Error(t, q.validateV8())
+	assert.Error(t, Live("publish_at", "").validateV8())
+}
+
+func TestWatchPublishRejectsQuery(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	id := func(f FruitR) int { return f.ID }
+
+	events, err := api.WatchPublish(context.Background(), Live("publish_at", ""), time.Hour, id, nil)
+	assert.Error(t, err, "v8 can't express Live")
+	assert.Nil(t, events)
+	_, err = api.WatchPublish(context.Background(), Limit(-2), time.Hour, id, nil)
+	assert.Error(t, err)
+
+	api.Lifecycle = &Lifecycle{}
+	require.NoError(t, api.Close(context.Background()))
+	_, err = api.WatchPublish(context.Background(), None(), time.Hour, id, nil)
+	assert.True(t, errors.Is(err, ErrClosed))
+}
@@ -1,24 +1,53 @@
 package directusapi
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/antoniobuconjic/directusapi/reldate"
 )
 
-type query struct {
+// Query is a builder of filters, sort, pagination and search of Items, start with None, the zero value
+// or any of the constructors like Eq. It's serialized for the Version of the API executing it.
+// Methods return modified copies, so a query can be shared as a base of other queries.
+// Filtered fields can be dot separated paths of relational fields, e.g. Eq("author.name", "Jane")
+// filters items by a field of their related author on the server.
+type Query struct {
 	eqFilter       map[string]string
 	nEqFilter      map[string]string
 	inFilter       map[string]string
 	containsFilter map[string]string
 	betweenFilter  map[string][]string
+	ltFilter       map[string]string
+	lteFilter      map[string]string
+	gtFilter       map[string]string
+	gteFilter      map[string]string
 	nNullFilter    []string
 	nullFilter     []string
-	sort           []string
-	limit          *int
-	offset         *int
-	searchStr      *string
+	// filters of other operators keyed by the operator, see filterOperators
+	opFilters map[string]map[string]string
+	sort      []string
+	limit     *int
+	offset    *int
+	page      *int
+	searchStr *string
+	// every group is joined with the other filters by AND,
+	// members of a group may contain groups themselves
+	groups []filterGroup
 	// relational objects query where key must be a dot separated path
 	deepQuery deepQuery
+	// queries of relational fields keyed by a dot separated path, see Deep
+	deep map[string]Query
+}
+
+// filterGroup is a group of queries joined by the logical operator op, _and or _or
+type filterGroup struct {
+	op      string
+	members []Query
 }
 
 type deepQuery struct {
@@ -31,166 +60,452 @@ type deepQuery struct {
 	offset      *keyVal[string, int]
 }
 
+// Now is a filter value resolved by the server to its current time, see package reldate for relative dates
+const Now = reldate.Now
+
 type keyVal[K, V any] struct {
 	key K
 	val V
 }
 
-func None() query {
-	return query{
+func None() Query {
+	return Query{
 		map[string]string{},
 		map[string]string{},
 		map[string]string{},
 		map[string]string{},
 		map[string][]string{},
+		map[string]string{},
+		map[string]string{},
+		map[string]string{},
+		map[string]string{},
 		[]string{},
 		[]string{},
+		map[string]map[string]string{},
 		[]string{},
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		deepQuery{},
+		nil,
 	}
 }
 
-func (q query) Null(k string) query {
-	q.nullFilter = append(q.nullFilter, k)
+func (q Query) Null(k string) Query {
+	q.nullFilter = appendCopy(q.nullFilter, k)
 	return q
 }
 
-func Null(k string) query {
+func Null(k string) Query {
 	return None().Null(k)
 }
 
-func (q query) Nnull(k string) query {
-	q.nNullFilter = append(q.nNullFilter, k)
+func (q Query) Nnull(k string) Query {
+	q.nNullFilter = appendCopy(q.nNullFilter, k)
 	return q
 }
 
-func Nnull(k string) query {
+func Nnull(k string) Query {
 	return None().Nnull(k)
 }
 
-func (q query) Neq(k, v string) query {
-	q.nEqFilter[k] = v
+func (q Query) Neq(k, v string) Query {
+	q.nEqFilter = withFilter(q.nEqFilter, k, v)
 	return q
 }
 
-func Neq(k, v string) query {
+func Neq(k, v string) Query {
 	return None().Neq(k, v)
 }
 
-func (q query) Eq(k, v string) query {
-	q.eqFilter[k] = v
+func (q Query) Eq(k, v string) Query {
+	q.eqFilter = withFilter(q.eqFilter, k, v)
 	return q
 }
 
-func (q query) Contains(k, v string) query {
-	q.containsFilter[k] = v
+func (q Query) Contains(k, v string) Query {
+	q.containsFilter = withFilter(q.containsFilter, k, v)
 	return q
 }
 
-func Eq(k, v string) query {
+func Eq(k, v string) Query {
 	return None().Eq(k, v)
 }
 
-func (q query) In(k, v string) query {
-	q.inFilter[k] = v
+func Contains(k, v string) Query {
+	return None().Contains(k, v)
+}
+
+func (q Query) In(k, v string) Query {
+	q.inFilter = withFilter(q.inFilter, k, v)
 	return q
 }
 
-func In(k, v string) query {
+func In(k, v string) Query {
 	return None().In(k, v)
 }
-func Between(k, v1, v2 string) query {
+func Between(k, v1, v2 string) Query {
 	return None().Between(k, v1, v2)
 }
 
-func (q query) Between(k, v1, v2 string) query {
-	q.betweenFilter[k] = []string{v1, v2}
+func (q Query) Between(k, v1, v2 string) Query {
+	q.betweenFilter = withFilter(q.betweenFilter, k, []string{v1, v2})
+	return q
+}
+
+func (q Query) Lt(k, v string) Query {
+	q.ltFilter = withFilter(q.ltFilter, k, v)
+	return q
+}
+
+func Lt(k, v string) Query {
+	return None().Lt(k, v)
+}
+
+func (q Query) Lte(k, v string) Query {
+	q.lteFilter = withFilter(q.lteFilter, k, v)
 	return q
 }
 
-func (q query) SortAsc(sortBy string) query {
-	q.sort = append(q.sort, sortBy)
+func Lte(k, v string) Query {
+	return None().Lte(k, v)
+}
+
+func (q Query) Gt(k, v string) Query {
+	q.gtFilter = withFilter(q.gtFilter, k, v)
 	return q
 }
 
-func SortAsc(sortBy string) query {
+func Gt(k, v string) Query {
+	return None().Gt(k, v)
+}
+
+func (q Query) Gte(k, v string) Query {
+	q.gteFilter = withFilter(q.gteFilter, k, v)
+	return q
+}
+
+func Gte(k, v string) Query {
+	return None().Gte(k, v)
+}
+
+// filterOperator describes how an operator of opFilters is serialized, operators are named
+// by v9 without the underscore prefix. v8 lacks some of them, they are emulated by similar ones.
+type filterOperator struct {
+	v8 string
+	// valueV8 adapts values to the v8 operator
+	valueV8 func(string) string
+	// list operators take comma separated values
+	list bool
+	// unary operators take no value
+	unary bool
+	// partial values are fragments of field values, e.g. of contains, which are not validated by schema
+	partial bool
+}
+
+var filterOperators = map[string]filterOperator{
+	"nin":          {v8: "nin", list: true},
+	"nbetween":     {v8: "nbetween", list: true},
+	"ncontains":    {v8: "ncontains", partial: true},
+	"icontains":    {v8: "contains", partial: true},
+	"starts_with":  {v8: "rlike", valueV8: func(v string) string { return v + "%" }, partial: true},
+	"nstarts_with": {v8: "nrlike", valueV8: func(v string) string { return v + "%" }, partial: true},
+	"ends_with":    {v8: "rlike", valueV8: func(v string) string { return "%" + v }, partial: true},
+	"nends_with":   {v8: "nrlike", valueV8: func(v string) string { return "%" + v }, partial: true},
+	"empty":        {v8: "empty", unary: true},
+	"nempty":       {v8: "nempty", unary: true},
+}
+
+func (q Query) opFilter(op, k, v string) Query {
+	q.opFilters = withFilter(q.opFilters, op, withFilter(q.opFilters[op], k, v))
+	return q
+}
+
+// Nin matches items whose field isn't any of comma separated values
+func (q Query) Nin(k, v string) Query {
+	return q.opFilter("nin", k, v)
+}
+
+func Nin(k, v string) Query {
+	return None().Nin(k, v)
+}
+
+// Nbetween matches items whose field is outside of the range
+func (q Query) Nbetween(k, v1, v2 string) Query {
+	return q.opFilter("nbetween", k, EscapeFilterValue(v1)+","+EscapeFilterValue(v2))
+}
+
+func Nbetween(k, v1, v2 string) Query {
+	return None().Nbetween(k, v1, v2)
+}
+
+func (q Query) Ncontains(k, v string) Query {
+	return q.opFilter("ncontains", k, v)
+}
+
+func Ncontains(k, v string) Query {
+	return None().Ncontains(k, v)
+}
+
+// Icontains is a case-insensitive Contains, v8 falls back to Contains
+// which is case-insensitive for the default collation of MySQL
+func (q Query) Icontains(k, v string) Query {
+	return q.opFilter("icontains", k, v)
+}
+
+func Icontains(k, v string) Query {
+	return None().Icontains(k, v)
+}
+
+func (q Query) StartsWith(k, v string) Query {
+	return q.opFilter("starts_with", k, v)
+}
+
+func StartsWith(k, v string) Query {
+	return None().StartsWith(k, v)
+}
+
+func (q Query) NstartsWith(k, v string) Query {
+	return q.opFilter("nstarts_with", k, v)
+}
+
+func NstartsWith(k, v string) Query {
+	return None().NstartsWith(k, v)
+}
+
+func (q Query) EndsWith(k, v string) Query {
+	return q.opFilter("ends_with", k, v)
+}
+
+func EndsWith(k, v string) Query {
+	return None().EndsWith(k, v)
+}
+
+func (q Query) NendsWith(k, v string) Query {
+	return q.opFilter("nends_with", k, v)
+}
+
+func NendsWith(k, v string) Query {
+	return None().NendsWith(k, v)
+}
+
+// Empty matches items whose field is null or empty, e.g. an empty string
+func (q Query) Empty(k string) Query {
+	return q.opFilter("empty", k, "")
+}
+
+func Empty(k string) Query {
+	return None().Empty(k)
+}
+
+func (q Query) Nempty(k string) Query {
+	return q.opFilter("nempty", k, "")
+}
+
+func Nempty(k string) Query {
+	return None().Nempty(k)
+}
+
+// Or adds a group of queries where at least one of them has to match.
+// Only filters of the given queries are used, groups can be nested by And and Or of the members.
+//
+// Directus v8 doesn't support grouping, it chains filters with the logical or operator of their fields instead,
+// so it supports only a single or group forming the whole query, with members of one filter on distinct fields.
+func (q Query) Or(qs ...Query) Query {
+	q.groups = appendCopy(q.groups, filterGroup{"_or", qs})
+	return q
+}
+
+func Or(qs ...Query) Query {
+	return None().Or(qs...)
+}
+
+// And adds a group of queries which all have to match, it's useful inside of Or groups,
+// e.g. Or(And(Eq("color", "red"), Lt("price", "5")), Eq("name", "kiwi")).
+//
+// Directus v8 doesn't support grouping, members are added to the filters of the query.
+func (q Query) And(qs ...Query) Query {
+	q.groups = appendCopy(q.groups, filterGroup{"_and", qs})
+	return q
+}
+
+func And(qs ...Query) Query {
+	return None().And(qs...)
+}
+
+func (q Query) SortAsc(sortBy string) Query {
+	q.sort = appendCopy(q.sort, sortBy)
+	return q
+}
+
+func SortAsc(sortBy string) Query {
 	return None().SortAsc(sortBy)
 }
 
-func (q query) SortDesc(sortBy string) query {
-	q.sort = append(q.sort, "-"+sortBy)
+func (q Query) SortDesc(sortBy string) Query {
+	q.sort = appendCopy(q.sort, "-"+sortBy)
 	return q
 }
 
-func SortDesc(sortBy string) query {
+func SortDesc(sortBy string) Query {
 	return None().SortDesc(sortBy)
 }
 
-func (q query) Limit(limit int) query {
+// AllItems is the limit of queries returning all items, it's the default of v9 queries,
+// v8 servers return 200 items when the limit isn't set.
+// Servers limiting queries by QUERY_LIMIT_MAX reject it, such collections need to be paged.
+const AllItems = -1
+
+// DefaultPageSize is the limit of queries with a page but without a limit
+const DefaultPageSize = 100
+
+// Limit sets the maximum number of returned items, use AllItems for all of them
+func (q Query) Limit(limit int) Query {
 	q.limit = &limit
 	return q
 }
 
-func Limit(limit int) query {
+func Limit(limit int) Query {
 	return None().Limit(limit)
 }
 
-func (q query) Offset(offset int) query {
+func (q Query) Offset(offset int) Query {
 	q.offset = &offset
 	return q
 }
 
-func Offset(offset int) query {
+func Offset(offset int) Query {
 	return None().Offset(offset)
 }
 
-func (q query) Search(str string) query {
+// Page returns a page of items, it starts at 1 and its size is the limit of the query
+// or DefaultPageSize when the limit isn't set
+func (q Query) Page(page int) Query {
+	q.page = &page
+	return q
+}
+
+func Page(page int) Query {
+	return None().Page(page)
+}
+
+// Search returns items containing the term in any of their text fields, e.g. of a search box.
+// It's sent as the search param of v9 and the q param of v8, an empty term removes the search.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#search
+func (q Query) Search(str string) Query {
+	if str == "" {
+		q.searchStr = nil
+		return q
+	}
 	q.searchStr = &str
 	return q
 }
 
-func Search(str string) query {
+func Search(str string) Query {
 	return None().Search(str)
 }
 
-func (q query) DeepEq(k, v string) query {
-	q.deepQuery.eqFilter[k] = v
+// Deep sets a query of a relational field, e.g. Deep("comments", SortDesc("date_created").Limit(3))
+// returns only the 3 most recent comments of every article. Filters, sort, pagination, search
+// and deep queries of rq are applied to the related items, the relation is a dot separated path.
+// A relation can be requested twice with different deep queries by an aliased field of the read model,
+// e.g. `json:"recent_comments,alias=comments"`.
+//
+// Only Directus v9 supports deep queries, they are ignored by v8.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#deep
+func (q Query) Deep(relation string, rq Query) Query {
+	q.deep = withFilter(q.deep, relation, rq)
+	return q
+}
+
+func Deep(relation string, rq Query) Query {
+	return None().Deep(relation, rq)
+}
+
+func (q Query) DeepEq(k, v string) Query {
+	q.deepQuery.eqFilter = withFilter(q.deepQuery.eqFilter, k, v)
 	return q
 }
 
-func (q query) DeepLimit(k string, limit int) query {
+func (q Query) DeepLimit(k string, limit int) Query {
 	q.deepQuery.limit = &keyVal[string, int]{k, limit}
 	return q
 }
 
-func (q query) DeepOffset(k string, offset int) query {
+func (q Query) DeepOffset(k string, offset int) Query {
 	q.deepQuery.offset = &keyVal[string, int]{k, offset}
 	return q
 }
 
-func (q query) asKeyValue(v Version) map[string]string {
+func (q Query) asKeyValue(v Version) map[string]string {
+	return q.asKeyValueAt(v, time.Now())
+}
+
+// asKeyValueAt serializes the query, relative dates are resolved to now for v8 which doesn't support them
+func (q Query) asKeyValueAt(v Version, now time.Time) map[string]string {
 	if v == V8 {
-		return q.asKeyValueV8()
+		return q.asKeyValueV8(now)
 	}
 	return q.asKeyValueV9()
 }
 
-func (q query) asKeyValueV8() map[string]string {
+func (q Query) asKeyValueV8(now time.Time) map[string]string {
 	out := map[string]string{}
+	q.filtersV8(out, now)
+	q.groupsV8(out, now)
+	if len(q.sort) > 0 {
+		out["sort"] = strings.Join(q.sort, ",")
+	}
+	if q.limit != nil {
+		out["limit"] = fmt.Sprint(*q.limit)
+	} else if q.page != nil {
+		out["limit"] = fmt.Sprint(DefaultPageSize)
+	}
+	if q.offset != nil {
+		out["offset"] = fmt.Sprint(*q.offset)
+	}
+	if q.page != nil {
+		out["page"] = fmt.Sprint(*q.page)
+	}
+	if q.searchStr != nil {
+		out["q"] = *q.searchStr
+	}
+	return out
+}
+
+func (q Query) filtersV8(out map[string]string, now time.Time) {
+	valueV8 := func(v string) string {
+		return valueAtV8(v, now)
+	}
 	for k, v := range q.eqFilter {
-		out[fmt.Sprintf("filter[%s][eq]", k)] = v
+		out[fmt.Sprintf("filter[%s][eq]", k)] = valueV8(v)
 	}
 	for k, v := range q.containsFilter {
-		out[fmt.Sprintf("filter[%s][contains]", k)] = v
+		out[fmt.Sprintf("filter[%s][contains]", k)] = valueV8(v)
 	}
 	for k, v := range q.nEqFilter {
-		out[fmt.Sprintf("filter[%s][neq]", k)] = v
+		out[fmt.Sprintf("filter[%s][neq]", k)] = valueV8(v)
 	}
 	for k, v := range q.inFilter {
-		out[fmt.Sprintf("filter[%s][in]", k)] = v
+		listParam(out, fmt.Sprintf("filter[%s][in]", k), splitList(v), valueV8)
+	}
+	for k, v := range q.ltFilter {
+		out[fmt.Sprintf("filter[%s][lt]", k)] = valueV8(v)
+	}
+	for k, v := range q.lteFilter {
+		out[fmt.Sprintf("filter[%s][lte]", k)] = valueV8(v)
+	}
+	for k, v := range q.gtFilter {
+		out[fmt.Sprintf("filter[%s][gt]", k)] = valueV8(v)
+	}
+	for k, v := range q.gteFilter {
+		out[fmt.Sprintf("filter[%s][gte]", k)] = valueV8(v)
 	}
 	for _, v := range q.nNullFilter {
 		out[fmt.Sprintf("filter[%s][nnull]", v)] = ""
@@ -199,59 +514,450 @@ func (q query) asKeyValueV8() map[string]string {
 		out[fmt.Sprintf("filter[%s][null]", v)] = ""
 	}
 	for k, v := range q.betweenFilter {
-		out[fmt.Sprintf("filter[%s][between]", k)] = strings.Join(v, ",")
+		listParam(out, fmt.Sprintf("filter[%s][between]", k), v, valueV8)
+	}
+	for op, filters := range q.opFilters {
+		o := filterOperators[op]
+		for k, v := range filters {
+			key := fmt.Sprintf("filter[%s][%s]", k, o.v8)
+			switch {
+			case o.unary:
+				out[key] = ""
+			case o.list:
+				listParam(out, key, splitList(v), valueV8)
+			case o.valueV8 != nil:
+				out[key] = o.valueV8(v)
+			default:
+				out[key] = valueV8(v)
+			}
+		}
+	}
+}
+
+// groupsV8 flattens the groups because v8 filters can't be nested,
+// members of or groups after the first one are chained by the logical or operator.
+// It's correct only for queries passing validateV8.
+func (q Query) groupsV8(out map[string]string, now time.Time) {
+	for _, group := range q.groups {
+		for i, member := range group.members {
+			member.filtersV8(out, now)
+			member.groupsV8(out, now)
+			if group.op != "_or" || i == 0 {
+				continue
+			}
+			for _, f := range member.filteredFields() {
+				out[fmt.Sprintf("filter[%s][logical]", f)] = "or"
+			}
+		}
+	}
+}
+
+// validateV8 rejects groups which v8 can't express. The logical or operator chains a field with all
+// filters before it, so an or group has to be the only filter of the query and its members single filters
+// of distinct fields. And groups are flattened, so they can't contain or groups.
+func (q Query) validateV8() error {
+	if !q.hasOrGroup() {
+		return nil
+	}
+	if len(q.filteredFields()) > 0 || len(q.groups) != 1 || q.groups[0].op != "_or" {
+		return errors.New("v8 supports an or group only as the only filter of a query")
 	}
+	seen := map[string]bool{}
+	for _, member := range q.groups[0].members {
+		fields := member.filteredFields()
+		if len(fields) != 1 || len(member.groups) > 0 || seen[fields[0]] {
+			return errors.New("v8 supports or groups only of single filters of distinct fields")
+		}
+		seen[fields[0]] = true
+	}
+	return nil
+}
+
+func (q Query) hasOrGroup() bool {
+	for _, group := range q.groups {
+		if group.op == "_or" {
+			return true
+		}
+		for _, member := range group.members {
+			if member.hasOrGroup() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (q Query) asKeyValueV9() map[string]string {
+	out := map[string]string{
+		"limit": "-1",
+	}
+	q.filtersV9("filter", out)
 	if len(q.sort) > 0 {
 		out["sort"] = strings.Join(q.sort, ",")
 	}
 	if q.limit != nil {
 		out["limit"] = fmt.Sprint(*q.limit)
+	} else if q.page != nil {
+		out["limit"] = fmt.Sprint(DefaultPageSize)
 	}
 	if q.offset != nil {
 		out["offset"] = fmt.Sprint(*q.offset)
 	}
+	if q.page != nil {
+		out["page"] = fmt.Sprint(*q.page)
+	}
+	if q.searchStr != nil {
+		out["search"] = *q.searchStr
+	}
+	q.parseDeepQuery(out)
+	q.deepParams("deep", out)
 	return out
 }
 
-func (q query) asKeyValueV9() map[string]string {
-	out := map[string]string{
-		"limit": "-1",
+// deepParams serializes queries of relational fields with the given prefix, nested ones recursively
+func (q Query) deepParams(prefix string, out map[string]string) {
+	for relation, rq := range q.deep {
+		p := prefix + parseV9Path(relation)
+		rq.filtersV9(p+"[_filter]", out)
+		if len(rq.sort) > 0 {
+			out[p+"[_sort]"] = strings.Join(rq.sort, ",")
+		}
+		if rq.limit != nil {
+			out[p+"[_limit]"] = fmt.Sprint(*rq.limit)
+		}
+		if rq.offset != nil {
+			out[p+"[_offset]"] = fmt.Sprint(*rq.offset)
+		}
+		if rq.page != nil {
+			out[p+"[_page]"] = fmt.Sprint(*rq.page)
+		}
+		if rq.searchStr != nil {
+			out[p+"[_search]"] = *rq.searchStr
+		}
+		rq.deepParams(p, out)
 	}
+}
+
+func (q Query) filtersV9(prefix string, out map[string]string) {
 	for k, v := range q.eqFilter {
-		out[fmt.Sprintf("filter%s[_eq]", parseV9Path(k))] = v
+		out[fmt.Sprintf("%s%s[_eq]", prefix, parseV9Path(k))] = v
 	}
 	for k, v := range q.containsFilter {
-		out[fmt.Sprintf("filter%s[_contains]", parseV9Path(k))] = v
+		out[fmt.Sprintf("%s%s[_contains]", prefix, parseV9Path(k))] = v
 	}
 	for k, v := range q.nEqFilter {
-		out[fmt.Sprintf("filter%s[_neq]", parseV9Path(k))] = v
+		out[fmt.Sprintf("%s%s[_neq]", prefix, parseV9Path(k))] = v
 	}
 	for k, v := range q.inFilter {
-		out[fmt.Sprintf("filter%s[_in]", parseV9Path(k))] = v
+		listParam(out, fmt.Sprintf("%s%s[_in]", prefix, parseV9Path(k)), splitList(v), nil)
+	}
+	for k, v := range q.ltFilter {
+		out[fmt.Sprintf("%s%s[_lt]", prefix, parseV9Path(k))] = v
+	}
+	for k, v := range q.lteFilter {
+		out[fmt.Sprintf("%s%s[_lte]", prefix, parseV9Path(k))] = v
+	}
+	for k, v := range q.gtFilter {
+		out[fmt.Sprintf("%s%s[_gt]", prefix, parseV9Path(k))] = v
+	}
+	for k, v := range q.gteFilter {
+		out[fmt.Sprintf("%s%s[_gte]", prefix, parseV9Path(k))] = v
 	}
 	for _, v := range q.nNullFilter {
-		out[fmt.Sprintf("filter%s[_nnull]", parseV9Path(v))] = "true"
+		out[fmt.Sprintf("%s%s[_nnull]", prefix, parseV9Path(v))] = "true"
 	}
 	for _, v := range q.nullFilter {
-		out[fmt.Sprintf("filter%s[_null]", parseV9Path(v))] = "true"
+		out[fmt.Sprintf("%s%s[_null]", prefix, parseV9Path(v))] = "true"
 	}
 	for k, v := range q.betweenFilter {
-		out[fmt.Sprintf("filter%s[_between]", parseV9Path(k))] = strings.Join(v, ",")
+		listParam(out, fmt.Sprintf("%s%s[_between]", prefix, parseV9Path(k)), v, nil)
 	}
-	if len(q.sort) > 0 {
-		out["sort"] = strings.Join(q.sort, ",")
+	for op, filters := range q.opFilters {
+		o := filterOperators[op]
+		for k, v := range filters {
+			key := fmt.Sprintf("%s%s[_%s]", prefix, parseV9Path(k), op)
+			switch {
+			case o.unary:
+				out[key] = "true"
+			case o.list:
+				listParam(out, key, splitList(v), nil)
+			default:
+				out[key] = v
+			}
+		}
 	}
-	if q.limit != nil {
-		out["limit"] = fmt.Sprint(*q.limit)
+	for i, group := range q.groups {
+		for j, member := range group.members {
+			member.filtersV9(fmt.Sprintf("%s[_and][%d][%s][%d]", prefix, i, group.op, j), out)
+		}
 	}
-	if q.offset != nil {
-		out["offset"] = fmt.Sprint(*q.offset)
+}
+
+// FilterJSON returns the filters of the query as the Directus v9 JSON filter object,
+// e.g. for permissions or presets. Values are strings as in query params,
+// the server casts them by the field type.
+func (q Query) FilterJSON() (json.RawMessage, error) {
+	params := map[string]string{}
+	q.filtersV9("", params)
+	root := map[string]any{}
+	for key, v := range params {
+		path := strings.Split(strings.TrimSuffix(strings.TrimPrefix(key, "["), "]"), "][")
+		node := root
+		for _, p := range path[:len(path)-1] {
+			child, ok := node[p].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[p] = child
+			}
+			node = child
+		}
+		op := path[len(path)-1]
+		switch {
+		case strings.HasSuffix(op, "between") || op == "_in" || op == "_nin":
+			items := []any{}
+			for _, item := range strings.Split(v, ",") {
+				items = append(items, item)
+			}
+			node[op] = items
+		case op == "_null" || op == "_nnull" || op == "_empty" || op == "_nempty":
+			node[op] = true
+		default:
+			node[op] = v
+		}
 	}
-	q.parseDeepQuery(out)
+	data, err := json.Marshal(filterArrays(root))
+	if err != nil {
+		return nil, fmt.Errorf("marshal filter: %w", err)
+	}
+	return data, nil
+}
+
+// filterArrays converts objects indexed from 0, like members of groups
+// and lists with escaped commas, to arrays
+func filterArrays(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	items := make([]any, len(m))
+	for k, child := range m {
+		m[k] = filterArrays(child)
+		if i, err := strconv.Atoi(k); err == nil && i >= 0 && i < len(items) && items != nil {
+			items[i] = m[k]
+		} else {
+			items = nil
+		}
+	}
+	if len(items) == 0 {
+		return m
+	}
+	return items
+}
+
+// Validate checks the query is consistent, Items validates queries before they are sent
+func (q Query) Validate() error {
+	if q.limit != nil && *q.limit < AllItems {
+		return fmt.Errorf("invalid limit %d, use -1 for all items", *q.limit)
+	}
+	if q.offset != nil && *q.offset < 0 {
+		return fmt.Errorf("invalid offset %d", *q.offset)
+	}
+	if q.page != nil {
+		if *q.page < 1 {
+			return fmt.Errorf("invalid page %d, pages start at 1", *q.page)
+		}
+		if q.offset != nil {
+			return errors.New("page and offset can't be combined")
+		}
+		if q.limit != nil && *q.limit == AllItems {
+			return errors.New("page needs a limit, all items are a single page")
+		}
+	}
+	for k, v := range q.betweenFilter {
+		if len(v) != 2 {
+			return fmt.Errorf("between filter of %s needs two values", k)
+		}
+	}
+	for k, v := range q.opFilters["nbetween"] {
+		if len(splitList(v)) != 2 {
+			return fmt.Errorf("nbetween filter of %s needs two values", k)
+		}
+	}
+	for _, f := range q.filteredFields() {
+		if f == "" {
+			return errors.New("filter without a field")
+		}
+		if err := validatePath(f); err != nil {
+			return err
+		}
+	}
+	for _, group := range q.groups {
+		for _, member := range group.members {
+			if err := member.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	for relation, rq := range q.deep {
+		if err := rq.Validate(); err != nil {
+			return fmt.Errorf("deep query of %s: %w", relation, err)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of q completed with filters, sort and pagination
+// of defaults which q doesn't set itself
+func (q Query) withDefaults(defaults Query) Query {
+	out := None()
+	mergeFilter(out.eqFilter, defaults.eqFilter, q.eqFilter)
+	mergeFilter(out.nEqFilter, defaults.nEqFilter, q.nEqFilter)
+	mergeFilter(out.inFilter, defaults.inFilter, q.inFilter)
+	mergeFilter(out.containsFilter, defaults.containsFilter, q.containsFilter)
+	mergeFilter(out.ltFilter, defaults.ltFilter, q.ltFilter)
+	mergeFilter(out.lteFilter, defaults.lteFilter, q.lteFilter)
+	mergeFilter(out.gtFilter, defaults.gtFilter, q.gtFilter)
+	mergeFilter(out.gteFilter, defaults.gteFilter, q.gteFilter)
+	mergeFilter(out.betweenFilter, defaults.betweenFilter, q.betweenFilter)
+	for _, filters := range []map[string]map[string]string{defaults.opFilters, q.opFilters} {
+		for op, f := range filters {
+			if out.opFilters[op] == nil {
+				out.opFilters[op] = map[string]string{}
+			}
+			mergeFilter(out.opFilters[op], f, nil)
+		}
+	}
+	out.nNullFilter = mergeFields(defaults.nNullFilter, q.nNullFilter)
+	out.nullFilter = mergeFields(defaults.nullFilter, q.nullFilter)
+	out.groups = append(append(out.groups, defaults.groups...), q.groups...)
+
+	out.sort = q.sort
+	if len(out.sort) == 0 {
+		out.sort = defaults.sort
+	}
+	out.limit, out.offset, out.page, out.searchStr = q.limit, q.offset, q.page, q.searchStr
+	if out.limit == nil {
+		out.limit = defaults.limit
+	}
+	if out.offset == nil && out.page == nil {
+		out.offset, out.page = defaults.offset, defaults.page
+	}
+	if out.searchStr == nil {
+		out.searchStr = defaults.searchStr
+	}
+	out.deepQuery = q.deepQuery
+	// deep queries of the same relation override the defaults as a whole
+	if len(defaults.deep) > 0 {
+		out.deep = make(map[string]Query, len(defaults.deep)+len(q.deep))
+		mergeFilter(out.deep, defaults.deep, q.deep)
+	} else {
+		out.deep = q.deep
+	}
+	return out
+}
+
+// withFilter returns a copy of filters with k set to v, so queries built from the same base don't share their maps
+func withFilter[V any](filters map[string]V, k string, v V) map[string]V {
+	out := make(map[string]V, len(filters)+1)
+	for key, val := range filters {
+		out[key] = val
+	}
+	out[k] = v
 	return out
 }
 
-func (q query) parseDeepQuery(out map[string]string) {
+// appendCopy appends items to a copy of s, so queries built from the same base don't share its array
+func appendCopy[T any](s []T, items ...T) []T {
+	return append(s[:len(s):len(s)], items...)
+}
+
+func mergeFilter[V any](dst, defaults, own map[string]V) {
+	for k, v := range defaults {
+		dst[k] = v
+	}
+	for k, v := range own {
+		dst[k] = v
+	}
+}
+
+func mergeFields(defaults, own []string) []string {
+	out := append([]string{}, own...)
+	for _, f := range defaults {
+		found := false
+		for _, o := range own {
+			found = found || o == f
+		}
+		if !found {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// eachFilterValue calls fn for every filtered field and its value,
+// list values of in and between filters are passed one by one
+func (q Query) eachFilterValue(fn func(field, value string)) {
+	for _, m := range []map[string]string{
+		q.eqFilter, q.nEqFilter,
+		q.ltFilter, q.lteFilter, q.gtFilter, q.gteFilter,
+	} {
+		for k, v := range m {
+			fn(k, v)
+		}
+	}
+	for k, v := range q.inFilter {
+		for _, item := range splitList(v) {
+			fn(k, item)
+		}
+	}
+	for k, v := range q.betweenFilter {
+		for _, item := range v {
+			fn(k, item)
+		}
+	}
+	for op, filters := range q.opFilters {
+		o := filterOperators[op]
+		if o.unary || o.partial {
+			continue
+		}
+		for k, v := range filters {
+			for _, item := range splitList(v) {
+				fn(k, item)
+			}
+		}
+	}
+	for _, group := range q.groups {
+		for _, member := range group.members {
+			member.eachFilterValue(fn)
+		}
+	}
+}
+
+// filteredFields returns all fields used by the query filters
+func (q Query) filteredFields() []string {
+	fields := []string{}
+	for _, m := range []map[string]string{
+		q.eqFilter, q.containsFilter, q.nEqFilter, q.inFilter,
+		q.ltFilter, q.lteFilter, q.gtFilter, q.gteFilter,
+	} {
+		for k := range m {
+			fields = append(fields, k)
+		}
+	}
+	for k := range q.betweenFilter {
+		fields = append(fields, k)
+	}
+	for _, filters := range q.opFilters {
+		for k := range filters {
+			fields = append(fields, k)
+		}
+	}
+	fields = append(fields, q.nNullFilter...)
+	fields = append(fields, q.nullFilter...)
+	return fields
+}
+
+func (q Query) parseDeepQuery(out map[string]string) {
 	for k, v := range q.deepQuery.eqFilter {
 		out[fmt.Sprintf("deep%s[_eq]", parseV9Path(k))] = v
 	}
@@ -259,7 +965,7 @@ func (q query) parseDeepQuery(out map[string]string) {
 		out[fmt.Sprintf("deep%s[_neq]", parseV9Path(k))] = v
 	}
 	for k, v := range q.deepQuery.inFilter {
-		out[fmt.Sprintf("deep%s[_in]", parseV9Path(k))] = v
+		listParam(out, fmt.Sprintf("deep%s[_in]", parseV9Path(k)), splitList(v), nil)
 	}
 	for _, v := range q.deepQuery.nNullFilter {
 		out[fmt.Sprintf("deep%s[_nnull]", parseV9Path(v))] = "true"
@@ -276,10 +982,87 @@ func (q query) parseDeepQuery(out map[string]string) {
 }
 
 func parseV9Path(path string) string {
-	split := strings.Split(path, ".")
+	split := splitPath(path)
 	paramPath := ""
 	for _, p := range split {
 		paramPath += "[" + p + "]"
 	}
 	return paramPath
 }
+
+// validatePath checks a field or a dot separated path of relational fields, e.g. author.role.name,
+// brackets aren't allowed because they would change the structure of v9 filter params
+func validatePath(path string) error {
+	if strings.ContainsAny(path, "[]") {
+		return fmt.Errorf("invalid field path %q", path)
+	}
+	for _, segment := range splitPath(path) {
+		if segment == "" {
+			return fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	return nil
+}
+
+// valueAtV8 translates filter values to their v8 representation,
+// relative dates are resolved to now of the client because v8 doesn't support them
+func valueAtV8(v string, now time.Time) string {
+	if v == Now {
+		return "now"
+	}
+	if t, ok := reldate.Resolve(v, now); ok {
+		return t.UTC().Format(datetimeFormat)
+	}
+	return v
+}
+
+// EscapeFilterValue escapes commas and backslashes of a single value of a list filter like In,
+// values of lists are separated by commas, so an escaped value containing commas isn't split
+func EscapeFilterValue(v string) string {
+	return filterValueEscaper.Replace(v)
+}
+
+var filterValueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`)
+
+// splitList splits a list filter value on commas which are not escaped by EscapeFilterValue
+func splitList(v string) []string {
+	if !strings.Contains(v, `\`) {
+		return strings.Split(v, ",")
+	}
+	var items []string
+	var item strings.Builder
+	for i := 0; i < len(v); i++ {
+		switch {
+		case v[i] == '\\' && i+1 < len(v) && (v[i+1] == ',' || v[i+1] == '\\'):
+			i++
+			item.WriteByte(v[i])
+		case v[i] == ',':
+			items = append(items, item.String())
+			item.Reset()
+		default:
+			item.WriteByte(v[i])
+		}
+	}
+	return append(items, item.String())
+}
+
+// listParam sets a list filter param, lists with values containing commas are sent
+// as indexed arrays because the server splits plain values on commas
+func listParam(out map[string]string, key string, values []string, value func(string) string) {
+	if value != nil {
+		mapped := make([]string, len(values))
+		for i, v := range values {
+			mapped[i] = value(v)
+		}
+		values = mapped
+	}
+	for _, v := range values {
+		if strings.Contains(v, ",") {
+			for i, v := range values {
+				out[fmt.Sprintf("%s[%d]", key, i)] = v
+			}
+			return
+		}
+	}
+	out[key] = strings.Join(values, ",")
+}
@@ -0,0 +1,299 @@
+package directusapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryWithDefaults(t *testing.T) {
+	defaults := Eq("status", "published").Nnull("name").SortAsc("sort").Limit(10)
+
+	q := Eq("category", "red").Eq("status", "draft").Offset(20).withDefaults(defaults)
+	assert.Equal(t, map[string]string{
+		"filter[status][_eq]":   "draft",
+		"filter[category][_eq]": "red",
+		"filter[name][_nnull]":  "true",
+		"sort":                  "sort",
+		"limit":                 "10",
+		"offset":                "20",
+	}, q.asKeyValue(V9))
+
+	// zero value defaults change nothing
+	var zero Query
+	assert.Equal(t, Eq("status", "draft").asKeyValue(V8), Eq("status", "draft").withDefaults(zero).asKeyValue(V8))
+
+	// defaults are not modified by merging
+	_ = defaults.withDefaults(zero).Eq("status", "archived")
+	assert.Equal(t, "published", defaults.eqFilter["status"])
+
+	// deep queries of defaults are kept unless the query sets the same relation
+	deepDefaults := Deep("translations", Eq("languages_code", "en")).Deep("comments", Limit(5))
+	merged := Deep("comments", Limit(1)).withDefaults(deepDefaults).asKeyValue(V9)
+	assert.Equal(t, "en", merged["deep[translations][_filter][languages_code][_eq]"])
+	assert.Equal(t, "1", merged["deep[comments][_limit]"])
+	assert.Len(t, deepDefaults.deep, 2)
+}
+
+func TestQuerySharedBase(t *testing.T) {
+	// a base with spare capacity of its slices, appends must not share the array
+	base := Eq("status", "published").Null("a").Null("b").Null("c").SortAsc("a").SortAsc("b").SortAsc("c").
+		Nin("color", "blue").Or(Eq("x", "1")).Or(Eq("y", "2")).Or(Eq("z", "3"))
+	want := base.asKeyValue(V9)
+
+	red := base.Eq("category", "red").Null("red").SortDesc("red").Nin("size", "S").Or(Eq("red", "1"))
+	green := base.Eq("category", "green").Null("green").SortDesc("green").Nin("size", "L").Or(Eq("green", "1"))
+	redParams, greenParams := red.asKeyValue(V9), green.asKeyValue(V9)
+
+	assert.Equal(t, want, base.asKeyValue(V9))
+	assert.Equal(t, "red", redParams["filter[category][_eq]"])
+	assert.Equal(t, "a,b,c,-red", redParams["sort"])
+	assert.Equal(t, "S", redParams["filter[size][_nin]"])
+	assert.Contains(t, redParams, "filter[red][_null]")
+	assert.NotContains(t, redParams, "filter[green][_null]")
+	assert.Equal(t, "green", greenParams["filter[category][_eq]"])
+	assert.Equal(t, "a,b,c,-green", greenParams["sort"])
+	assert.Equal(t, "L", greenParams["filter[size][_nin]"])
+	assert.NotContains(t, greenParams, "filter[red][_null]")
+	assert.Len(t, red.groups, 4)
+	assert.Equal(t, "green", green.groups[3].members[0].filteredFields()[0])
+
+	// the zero value is usable
+	var zero Query
+	assert.Equal(t, Eq("name", "kiwi").Between("price", "1", "2").Empty("note").asKeyValue(V9),
+		zero.Eq("name", "kiwi").Between("price", "1", "2").Empty("note").asKeyValue(V9))
+}
+
+// serverList parses a list filter param the way the server does
+func serverList(t *testing.T, rawQuery, key string) []string {
+	qv, err := url.ParseQuery(rawQuery)
+	require.NoError(t, err)
+	if qv.Has(key) {
+		return strings.Split(qv.Get(key), ",")
+	}
+	var out []string
+	for i := 0; qv.Has(fmt.Sprintf("%s[%d]", key, i)); i++ {
+		out = append(out, qv.Get(fmt.Sprintf("%s[%d]", key, i)))
+	}
+	return out
+}
+
+func TestListFilterValues(t *testing.T) {
+	values := []string{`a,b`, `"quoted"`, `[bracket]`, `back\slash`, `žluťoučký 🍓`, ``}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = EscapeFilterValue(v)
+	}
+	q := In("name", strings.Join(escaped, ",")).Between("weight", "1,5", "2")
+
+	v9 := encodeQuery(q.asKeyValue(V9))
+	assert.Equal(t, values, serverList(t, v9, "filter[name][_in]"))
+	assert.Equal(t, []string{"1,5", "2"}, serverList(t, v9, "filter[weight][_between]"))
+	v8 := encodeQuery(q.asKeyValue(V8))
+	assert.Equal(t, values, serverList(t, v8, "filter[name][in]"))
+
+	plain := In("id", "1,2,3").asKeyValue(V9)
+	assert.Equal(t, "1,2,3", plain["filter[id][_in]"])
+}
+
+func FuzzListFilterValues(f *testing.F) {
+	f.Add("kiwi", "lime")
+	f.Add("a,b", `c\,d`)
+	f.Add(`\`, `"[]"`)
+	f.Add("🍓", "")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		if !utf8.ValidString(a) || !utf8.ValidString(b) {
+			t.Skip("query values are encoded as UTF-8")
+		}
+		list := EscapeFilterValue(a) + "," + EscapeFilterValue(b)
+		assert.Equal(t, []string{a, b}, splitList(list))
+		assert.Equal(t, []string{a, b}, serverList(t, encodeQuery(In("name", list).asKeyValue(V9)), "filter[name][_in]"))
+		if !strings.HasPrefix(a, Now) && !strings.HasPrefix(b, Now) {
+			// v8 resolves relative dates on the client
+			assert.Equal(t, []string{a, b}, serverList(t, encodeQuery(In("name", list).asKeyValue(V8)), "filter[name][in]"))
+		}
+	})
+}
+
+func TestQueryPagination(t *testing.T) {
+	q := Limit(25).Page(3).Search("kiwi")
+	assert.Equal(t, map[string]string{"limit": "25", "page": "3", "search": "kiwi"}, q.asKeyValue(V9))
+	assert.Equal(t, map[string]string{"limit": "25", "page": "3", "q": "kiwi"}, q.asKeyValue(V8))
+	assert.Equal(t, map[string]string{"limit": "25", "page": "3"}, q.Search("").asKeyValue(V8))
+
+	// a page replaces the default offset
+	assert.Equal(t, map[string]string{"limit": "25", "page": "3"}, Page(3).withDefaults(Limit(25).Offset(50)).asKeyValue(V8))
+
+	// a page without a limit has the default size
+	assert.Equal(t, map[string]string{"limit": "100", "page": "2"}, Page(2).asKeyValue(V9))
+	assert.Equal(t, map[string]string{"limit": "100", "page": "2"}, Page(2).asKeyValue(V8))
+	assert.Equal(t, map[string]string{"limit": "-1"}, None().asKeyValue(V9))
+	assert.Equal(t, map[string]string{"limit": "-1"}, Limit(AllItems).asKeyValue(V8))
+}
+
+func TestQueryValidate(t *testing.T) {
+	assert.NoError(t, Eq("status", "published").Limit(-1).Offset(10).Validate())
+	assert.NoError(t, Limit(10).Page(1).Validate())
+
+	for name, q := range map[string]Query{
+		"limit":          Limit(-2),
+		"offset":         Offset(-1),
+		"page":           Page(0),
+		"page offset":    Page(2).Offset(10),
+		"page all items": Page(2).Limit(AllItems),
+		"between":        {betweenFilter: map[string][]string{"weight": {"1"}}},
+		"empty field":    Eq("", "kiwi"),
+		"empty in group": Or(Eq("name", "kiwi"), Null("")),
+	} {
+		assert.Error(t, q.Validate(), name)
+	}
+}
+
+func TestFilterOperators(t *testing.T) {
+	q := Nin("status", "draft,archived").
+		Nbetween("weight", "1", "5").
+		Contains("name", "iw").
+		Ncontains("name", "x").
+		Icontains("category", "Red").
+		StartsWith("name", "ki").
+		NstartsWith("name", "z").
+		EndsWith("email", "@example.com").
+		NendsWith("email", ".test").
+		Empty("area").
+		Nempty("price")
+
+	assert.Equal(t, map[string]string{
+		"limit":                        "-1",
+		"filter[status][_nin]":         "draft,archived",
+		"filter[weight][_nbetween]":    "1,5",
+		"filter[name][_contains]":      "iw",
+		"filter[name][_ncontains]":     "x",
+		"filter[category][_icontains]": "Red",
+		"filter[name][_starts_with]":   "ki",
+		"filter[name][_nstarts_with]":  "z",
+		"filter[email][_ends_with]":    "@example.com",
+		"filter[email][_nends_with]":   ".test",
+		"filter[area][_empty]":         "true",
+		"filter[price][_nempty]":       "true",
+	}, q.asKeyValue(V9))
+
+	// v8 has no operator for the beginning or end of a value, like patterns are used instead
+	q = Nin("status", "draft,archived").Nbetween("weight", "1", "5").Icontains("category", "Red").
+		StartsWith("name", "ki").NendsWith("email", ".test").Empty("area")
+	assert.Equal(t, map[string]string{
+		"filter[status][nin]":        "draft,archived",
+		"filter[weight][nbetween]":   "1,5",
+		"filter[category][contains]": "Red",
+		"filter[name][rlike]":        "ki%",
+		"filter[email][nrlike]":      "%.test",
+		"filter[area][empty]":        "",
+	}, q.asKeyValue(V8))
+
+	// defaults are merged by operator and field
+	merged := Nin("status", "draft").withDefaults(Nin("status", "archived").Empty("area"))
+	assert.Equal(t, map[string]string{
+		"limit":                "-1",
+		"filter[status][_nin]": "draft",
+		"filter[area][_empty]": "true",
+	}, merged.asKeyValue(V9))
+
+	assert.Error(t, Query{opFilters: map[string]map[string]string{"nbetween": {"weight": "1"}}}.Validate())
+}
+
+func TestNestedFilterGroups(t *testing.T) {
+	q := Eq("status", "published").Or(
+		And(Eq("color", "red"), Lt("price", "5")),
+		Eq("name", "kiwi").Or(Null("color"), In("weight", "1,2")),
+	)
+
+	assert.Equal(t, map[string]string{
+		"limit":               "-1",
+		"filter[status][_eq]": "published",
+		"filter[_and][0][_or][0][_and][0][_and][0][color][_eq]":  "red",
+		"filter[_and][0][_or][0][_and][0][_and][1][price][_lt]":  "5",
+		"filter[_and][0][_or][1][name][_eq]":                     "kiwi",
+		"filter[_and][0][_or][1][_and][0][_or][0][color][_null]": "true",
+		"filter[_and][0][_or][1][_and][0][_or][1][weight][_in]":  "1,2",
+	}, q.asKeyValue(V9))
+
+	// v8 can't nest groups and its logical or applies to all preceding filters
+	assert.Error(t, q.validateV8())
+	assert.Error(t, Or(Eq("name", "kiwi"), Null("name")).validateV8())
+	assert.Error(t, Or(Eq("name", "kiwi"), And(Null("color"), Lt("price", "5"))).validateV8())
+	assert.NoError(t, Eq("status", "published").And(Eq("color", "red"), Lt("price", "5")).validateV8())
+
+	single := Or(Eq("name", "kiwi"), Null("color"), In("weight", "1,2"))
+	require.NoError(t, single.validateV8())
+	assert.Equal(t, map[string]string{
+		"filter[name][eq]":        "kiwi",
+		"filter[color][null]":     "",
+		"filter[color][logical]":  "or",
+		"filter[weight][in]":      "1,2",
+		"filter[weight][logical]": "or",
+	}, single.asKeyValue(V8))
+
+	filter, err := q.FilterJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": {"_eq": "published"},
+		"_and": [{"_or": [
+			{"_and": [{"_and": [{"color": {"_eq": "red"}}, {"price": {"_lt": "5"}}]}]},
+			{"name": {"_eq": "kiwi"}, "_and": [{"_or": [{"color": {"_null": true}}, {"weight": {"_in": ["1", "2"]}}]}]}
+		]}]
+	}`, string(filter))
+
+	require.Error(t, Or(And(Eq("name", "kiwi"), Null(""))).Validate())
+}
+
+func TestDeepQuery(t *testing.T) {
+	q := Eq("status", "published").Deep("comments", Eq("approved", "true").SortDesc("date_created").Limit(3).
+		Deep("author", Nnull("avatar")))
+	assert.Equal(t, map[string]string{
+		"limit":                                           "-1",
+		"filter[status][_eq]":                             "published",
+		"deep[comments][_filter][approved][_eq]":          "true",
+		"deep[comments][_sort]":                           "-date_created",
+		"deep[comments][_limit]":                          "3",
+		"deep[comments][author][_filter][avatar][_nnull]": "true",
+	}, q.asKeyValue(V9))
+	assert.Equal(t, map[string]string{"filter[status][eq]": "published"}, q.asKeyValue(V8))
+
+	// deep queries are copied, queries built from the same base don't share them
+	base := None().DeepEq("comments.approved", "true")
+	a := base.Deep("tags", Limit(1)).DeepEq("comments.id", "1")
+	assert.Len(t, base.deep, 0)
+	assert.Len(t, base.deepQuery.eqFilter, 1)
+	assert.Len(t, a.deepQuery.eqFilter, 2)
+
+	assert.ErrorContains(t, Deep("comments", Limit(-2)).Validate(), "deep query of comments")
+}
+
+func TestRelationalFilters(t *testing.T) {
+	q := Eq("author.name", "Jane").In("author.role.name", "editor,admin").Null("author.email")
+	assert.Equal(t, map[string]string{
+		"filter[author.name][eq]":      "Jane",
+		"filter[author.role.name][in]": "editor,admin",
+		"filter[author.email][null]":   "",
+	}, q.asKeyValue(V8))
+	assert.Equal(t, map[string]string{
+		"filter[author][name][_eq]":       "Jane",
+		"filter[author][role][name][_in]": "editor,admin",
+		"filter[author][email][_null]":    "true",
+		"limit":                           "-1",
+	}, q.asKeyValue(V9))
+
+	filter, err := q.FilterJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"author":{"name":{"_eq":"Jane"},"role":{"name":{"_in":["editor","admin"]}},"email":{"_null":true}}}`, string(filter))
+
+	assert.NoError(t, Eq(Year("author.date_created"), "2023").Validate())
+	for _, path := range []string{"author..name", ".name", "author.", "author[name]"} {
+		assert.ErrorContains(t, Eq(path, "x").Validate(), "invalid field path", path)
+	}
+	assert.Error(t, Or(Eq("author.", "x")).Validate())
+}
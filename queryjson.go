@@ -0,0 +1,144 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// queryJSON is the JSON representation of Query, maps are marshaled with sorted keys,
+// so equal queries have the same representation
+type queryJSON struct {
+	// Filters are values of fields keyed by the operator, e.g. eq or starts_with
+	Filters    map[string]map[string]string `json:"filters,omitempty"`
+	Between    map[string][]string          `json:"between,omitempty"`
+	Null       []string                     `json:"null,omitempty"`
+	Nnull      []string                     `json:"nnull,omitempty"`
+	Groups     []groupJSON                  `json:"groups,omitempty"`
+	Sort       []string                     `json:"sort,omitempty"`
+	Limit      *int                         `json:"limit,omitempty"`
+	Offset     *int                         `json:"offset,omitempty"`
+	Page       *int                         `json:"page,omitempty"`
+	Search     *string                      `json:"search,omitempty"`
+	Deep       map[string]Query             `json:"deep,omitempty"`
+	DeepEq     map[string]string            `json:"deep_eq,omitempty"`
+	DeepLimit  *deepPaginationJSON          `json:"deep_limit,omitempty"`
+	DeepOffset *deepPaginationJSON          `json:"deep_offset,omitempty"`
+}
+
+type groupJSON struct {
+	// Op is and or or
+	Op      string  `json:"op"`
+	Members []Query `json:"members"`
+}
+
+type deepPaginationJSON struct {
+	Field string `json:"field"`
+	Value int    `json:"value"`
+}
+
+// builtinFilters returns filters of operators with their own fields of Query keyed by the operator
+func (q Query) builtinFilters() map[string]map[string]string {
+	return map[string]map[string]string{
+		"eq":       q.eqFilter,
+		"neq":      q.nEqFilter,
+		"in":       q.inFilter,
+		"contains": q.containsFilter,
+		"lt":       q.ltFilter,
+		"lte":      q.lteFilter,
+		"gt":       q.gtFilter,
+		"gte":      q.gteFilter,
+	}
+}
+
+// MarshalJSON returns a stable JSON representation of the query, e.g. for config files or logs,
+// which is parsed back by UnmarshalJSON
+func (q Query) MarshalJSON() ([]byte, error) {
+	out := queryJSON{
+		Filters:    map[string]map[string]string{},
+		Between:    q.betweenFilter,
+		Null:       q.nullFilter,
+		Nnull:      q.nNullFilter,
+		Sort:       q.sort,
+		Limit:      q.limit,
+		Offset:     q.offset,
+		Page:       q.page,
+		Search:     q.searchStr,
+		Deep:       q.deep,
+		DeepEq:     q.deepQuery.eqFilter,
+		DeepLimit:  deepPagination(q.deepQuery.limit),
+		DeepOffset: deepPagination(q.deepQuery.offset),
+	}
+	for op, filters := range q.builtinFilters() {
+		if len(filters) > 0 {
+			out.Filters[op] = filters
+		}
+	}
+	for op, filters := range q.opFilters {
+		if len(filters) > 0 {
+			out.Filters[op] = filters
+		}
+	}
+	for _, g := range q.groups {
+		out.Groups = append(out.Groups, groupJSON{g.op[1:], g.members})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON parses a query marshaled by MarshalJSON, unknown operators are rejected
+func (q *Query) UnmarshalJSON(b []byte) error {
+	var in queryJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	out := None()
+	builtin := out.builtinFilters()
+	for op, filters := range in.Filters {
+		for k, v := range filters {
+			if dest, ok := builtin[op]; ok {
+				dest[k] = v
+			} else if _, ok := filterOperators[op]; ok {
+				out = out.opFilter(op, k, v)
+			} else {
+				return fmt.Errorf("unknown filter operator %q", op)
+			}
+		}
+	}
+	for k, v := range in.Between {
+		out.betweenFilter[k] = v
+	}
+	out.nullFilter = append(out.nullFilter, in.Null...)
+	out.nNullFilter = append(out.nNullFilter, in.Nnull...)
+	for _, g := range in.Groups {
+		switch g.Op {
+		case "and":
+			out = out.And(g.Members...)
+		case "or":
+			out = out.Or(g.Members...)
+		default:
+			return fmt.Errorf("unknown group operator %q", g.Op)
+		}
+	}
+	out.sort = append(out.sort, in.Sort...)
+	out.limit, out.offset, out.page, out.searchStr = in.Limit, in.Offset, in.Page, in.Search
+	for relation, rq := range in.Deep {
+		out = out.Deep(relation, rq)
+	}
+	for k, v := range in.DeepEq {
+		out = out.DeepEq(k, v)
+	}
+	if in.DeepLimit != nil {
+		out = out.DeepLimit(in.DeepLimit.Field, in.DeepLimit.Value)
+	}
+	if in.DeepOffset != nil {
+		out = out.DeepOffset(in.DeepOffset.Field, in.DeepOffset.Value)
+	}
+	*q = out
+	return nil
+}
+
+func deepPagination(kv *keyVal[string, int]) *deepPaginationJSON {
+	if kv == nil {
+		return nil
+	}
+	return &deepPaginationJSON{kv.key, kv.val}
+}
@@ -0,0 +1,39 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryJSON(t *testing.T) {
+	b, err := json.Marshal(Eq("status", "published").Null("deleted_at").SortDesc("id").Limit(10))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"filters":{"eq":{"status":"published"}},"null":["deleted_at"],"sort":["-id"],"limit":10}`, string(b))
+
+	q := Eq("status", "published").Neq("name", "fig").In("id", "1,2").Contains("name", "ki").
+		Between("weight", "1", "5").Lt("a", "1").Lte("b", "2").Gt("c", "3").Gte("d", "4").
+		Nnull("category").StartsWith("name", "k").Nbetween("price", "1", "2").Empty("notes").
+		Or(Eq("color", "red"), And(Eq("color", "green"), Lt("price", "5"))).
+		SortAsc("name").Offset(5).Search("kiwi").
+		Deep("comments", SortDesc("date_created").Limit(3)).
+		DeepEq("tags.name", "fresh").DeepLimit("tags", 2).DeepOffset("tags", 1)
+	b, err = json.Marshal(q)
+	require.NoError(t, err)
+
+	var parsed Query
+	require.NoError(t, json.Unmarshal(b, &parsed))
+	assert.Equal(t, q.asKeyValue(V8), parsed.asKeyValue(V8))
+	assert.Equal(t, q.asKeyValue(V9), parsed.asKeyValue(V9))
+	again, err := json.Marshal(parsed)
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(again), "representation should be stable")
+
+	// parsed queries can be extended like built ones
+	assert.NotPanics(t, func() { parsed.Eq("name", "kiwi").Nin("id", "3") })
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"filters":{"like":{"name":"k"}}}`), &parsed), "unknown filter operator")
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"groups":[{"op":"xor","members":[]}]}`), &parsed), "unknown group operator")
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package directusapi
+
+// the race detector instruments allocations, so budgets are not checked under it
+const raceEnabled = true
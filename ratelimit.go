@@ -0,0 +1,105 @@
+package directusapi
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultRateLimitThreshold = 0.2
+
+// RateLimit is the state of the server's rate limiter as reported by response headers
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	// Reset is when the budget is renewed
+	Reset time.Time
+}
+
+// RateLimiter tracks RateLimit-* and X-RateLimit-* headers of responses and delays requests
+// once the remaining budget shrinks below Threshold, so requests are spread over the rest
+// of the window instead of failing with 429 at its end. It can be shared by API instances
+// of the same Directus instance, headers are sent only when its rate limiter is enabled.
+type RateLimiter struct {
+	// Threshold is a fraction of the limit below which requests are delayed, defaults to 0.2
+	Threshold float64
+
+	mu    sync.Mutex
+	state RateLimit
+}
+
+// State returns the rate limit reported by the latest response
+func (l *RateLimiter) State() RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// update reads rate limit headers of a response, responses without them are ignored
+func (l *RateLimiter) update(h http.Header, now time.Time) {
+	if l == nil {
+		return
+	}
+	remaining, err := strconv.Atoi(rateLimitHeader(h, "Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(rateLimitHeader(h, "Limit"))
+	state := RateLimit{Limit: limit, Remaining: remaining}
+	reset := rateLimitHeader(h, "Reset")
+	if s, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		// seconds until the reset, big values are unix timestamps
+		if s > 1e9 {
+			state.Reset = time.Unix(s, 0)
+		} else {
+			state.Reset = now.Add(time.Duration(s) * time.Second)
+		}
+	} else if t, err := http.ParseTime(reset); err == nil {
+		state.Reset = t
+	} else if t, err := time.Parse(time.RFC3339, reset); err == nil {
+		state.Reset = t
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.state = state
+}
+
+func rateLimitHeader(h http.Header, name string) string {
+	if v := h.Get("RateLimit-" + name); v != "" {
+		return v
+	}
+	return h.Get("X-RateLimit-" + name)
+}
+
+// delay returns how long the next request should wait, the rest of the window
+// is split evenly among the remaining requests
+func (l *RateLimiter) delay(now time.Time) time.Duration {
+	return l.delayBelow(now, 0)
+}
+
+// delayBelow is delay with threshold replacing Threshold unless it's zero, e.g. of LiveConfig
+func (l *RateLimiter) delayBelow(now time.Time, threshold float64) time.Duration {
+	if l == nil {
+		return 0
+	}
+	s := l.State()
+	untilReset := s.Reset.Sub(now)
+	if s.Limit <= 0 || untilReset <= 0 {
+		return 0
+	}
+	if threshold == 0 {
+		threshold = l.Threshold
+	}
+	if threshold == 0 {
+		threshold = defaultRateLimitThreshold
+	}
+	if float64(s.Remaining) >= threshold*float64(s.Limit) {
+		return 0
+	}
+	if s.Remaining <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(s.Remaining+1)
+}
@@ -0,0 +1,56 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC)
+
+	t.Run("headers", func(t *testing.T) {
+		var l RateLimiter
+		l.update(http.Header{"Ratelimit-Limit": {"50"}, "Ratelimit-Remaining": {"7"}, "Ratelimit-Reset": {"30"}}, now)
+		assert.Equal(t, RateLimit{50, 7, now.Add(30 * time.Second)}, l.State())
+
+		l.update(http.Header{"X-Ratelimit-Limit": {"50"}, "X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {"Thu, 05 May 2022 10:01:00 GMT"}}, now)
+		assert.Equal(t, 3, l.State().Remaining)
+		assert.True(t, now.Add(time.Minute).Equal(l.State().Reset))
+
+		l.update(http.Header{}, now)
+		assert.Equal(t, 3, l.State().Remaining, "responses without headers are ignored")
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		l := RateLimiter{state: RateLimit{100, 50, now.Add(10 * time.Second)}}
+		assert.Zero(t, l.delay(now))
+
+		l.state.Remaining = 9
+		assert.Equal(t, time.Second, l.delay(now))
+
+		l.state.Remaining = 0
+		assert.Equal(t, 10*time.Second, l.delay(now))
+		assert.Zero(t, l.delay(now.Add(10*time.Second)))
+
+		var nilLimiter *RateLimiter
+		assert.Zero(t, nilLimiter.delay(now))
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("RateLimit-Limit", "50")
+			w.Header().Set("RateLimit-Remaining", "49")
+			w.Header().Set("RateLimit-Reset", "1")
+			_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+		}))
+		api.RateLimiter = &RateLimiter{}
+		_, err := api.GetByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, 49, api.RateLimiter.State().Remaining)
+	})
+}
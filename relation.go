@@ -0,0 +1,127 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Relation is a list of related items of an O2M or M2M field, e.g. ids of tags in a write model
+// or tags in a read model. Unlike a slice it tells an unchanged relation from an empty one:
+//
+//  1. keep related items unchanged
+//     => zero value of Relation[T], the field is left out of the request body
+//  2. remove all related items
+//     => ClearRelation[int]()
+//  3. replace related items
+//     => SetRelation(1, 2)
+//
+// In read models null decodes to an unset relation and an empty array to a set one without items,
+// unset relations encode back to null. Bodies of writes and partials of Create and Update leave them out.
+type Relation[T any] struct {
+	items []T
+	op    operation
+}
+
+// SetRelation replaces related items by items, without items it removes all of them
+func SetRelation[T any](items ...T) Relation[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Relation[T]{items, set}
+}
+
+// ClearRelation removes all related items
+func ClearRelation[T any]() Relation[T] {
+	return SetRelation[T]()
+}
+
+// Items returns related items, it's nil when the relation isn't set and non-nil otherwise
+func (r Relation[T]) Items() []T {
+	if r.op != set {
+		return nil
+	}
+	return r.items
+}
+
+// ItemsOrEmpty returns related items, it's an empty slice instead of nil when the relation isn't set
+func (r Relation[T]) ItemsOrEmpty() []T {
+	if r.op != set {
+		return []T{}
+	}
+	return r.items
+}
+
+func (r Relation[T]) IsSet() bool {
+	return r.op == set
+}
+
+func (r Relation[T]) MarshalJSON() ([]byte, error) {
+	if r.op != set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.items)
+}
+
+func (r *Relation[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = Relation[T]{}
+		return nil
+	}
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*r = SetRelation(items...)
+	return nil
+}
+
+func (r Relation[T]) getOp() operation {
+	return r.op
+}
+
+func (r Relation[T]) fields(prefix string) []string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if _, hooked := lookupTypeHook(t); t.Kind() == reflect.Struct && !hooked && !t.ConvertibleTo(reflect.TypeOf(Time{})) {
+		return iterateFields(t, prefix)
+	}
+	return []string{prefix}
+}
+
+type isRelation interface {
+	isOpt
+	relation()
+}
+
+func (r Relation[T]) relation() {}
+
+var relationType = reflect.TypeOf((*isRelation)(nil)).Elem()
+
+// unchangedRelation reports relations left out of request bodies
+func unchangedRelation(v reflect.Value) bool {
+	if !v.Type().Implements(relationType) {
+		return false
+	}
+	return v.Interface().(isRelation).getOp() != set
+}
+
+// withoutUnchangedRelations returns partials without unchanged relations,
+// they would be encoded as null which removes all related items
+func withoutUnchangedRelations(partials map[string]any) map[string]any {
+	unchanged := func(v any) bool {
+		r, ok := v.(isRelation)
+		return ok && r.getOp() != set
+	}
+	for _, v := range partials {
+		if !unchanged(v) {
+			continue
+		}
+		out := make(map[string]any, len(partials))
+		for k, v := range partials {
+			if !unchanged(v) {
+				out[k] = v
+			}
+		}
+		return out
+	}
+	return partials
+}
@@ -0,0 +1,84 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type articleW struct {
+	Title string         `json:"title"`
+	Tags  Relation[int]  `json:"tags"`
+	Notes []articleNoteW `json:"notes"`
+}
+
+type articleNoteW struct {
+	Text    string        `json:"text"`
+	Authors Relation[int] `json:"authors"`
+}
+
+type articleR struct {
+	ID   int             `json:"id"`
+	Tags Relation[UserR] `json:"tags"`
+}
+
+func TestRelation(t *testing.T) {
+	encode := func(w articleW) string {
+		body, err := marshalBody(w)
+		require.NoError(t, err)
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		return string(b)
+	}
+	assert.JSONEq(t, `{"title":"a","notes":null}`, encode(articleW{Title: "a"}), "unchanged relations are left out")
+	assert.JSONEq(t, `{"title":"a","tags":[],"notes":[{"text":"n"}]}`,
+		encode(articleW{Title: "a", Tags: ClearRelation[int](), Notes: []articleNoteW{{Text: "n"}}}))
+	assert.JSONEq(t, `{"title":"a","tags":[1,2],"notes":null}`, encode(articleW{Title: "a", Tags: SetRelation(1, 2)}))
+
+	var bodies []string
+	api := API[articleR, articleW, int]{
+		Scheme:         "http",
+		Namespace:      "_",
+		CollectionName: "articles",
+	}
+	srv := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		_, _ = w.Write([]byte(`{"data":{"id":1,"tags":null}}`))
+	}))
+	api.Host, api.HTTPClient = srv.Host, srv.HTTPClient
+
+	item, err := api.Set(context.Background(), 1, articleW{Title: "a"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"a","notes":null}`, bodies[0])
+	assert.False(t, item.Tags.IsSet())
+	assert.Nil(t, item.Tags.Items())
+	assert.Equal(t, []UserR{}, item.Tags.ItemsOrEmpty())
+
+	// unchanged relations of partials are left out, null would wipe related items
+	_, err = api.Update(context.Background(), 1, map[string]any{"title": "b", "tags": Relation[int]{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"b"}`, bodies[1])
+	_, err = api.Create(context.Background(), map[string]any{"title": "c", "tags": Relation[int]{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"c"}`, bodies[2])
+
+	// read items encode back like they were decoded
+	b, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"tags":null}`, string(b))
+
+	var r articleR
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"tags":[]}`), &r))
+	assert.True(t, r.Tags.IsSet())
+	assert.Equal(t, []UserR{}, r.Tags.Items())
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"tags":[{"id":2}]}`), &r))
+	assert.Equal(t, []UserR{{ID: 2}}, r.Tags.Items())
+
+	assert.Equal(t, []string{"id", "tags.id", "tags.email"}, api.jsonFieldsR())
+}
@@ -0,0 +1,43 @@
+package directusapi
+
+import (
+	"time"
+
+	"github.com/antoniobuconjic/directusapi/reldate"
+)
+
+// NowOffset returns a filter value resolved to the current time shifted by d.
+// Directus v8 doesn't support relative dates, the value is resolved by the client when the query is sent.
+//
+// Deprecated: use reldate.Offset.
+func NowOffset(d time.Duration) string {
+	return reldate.Offset(d)
+}
+
+// Dynamic variables of the current user resolved by the server, fields of the user or role
+// are selected by a dot separated path, e.g. CurrentUser + ".team".
+// Directus v8 doesn't support them.
+const (
+	CurrentUser = "$CURRENT_USER"
+	CurrentRole = "$CURRENT_ROLE"
+)
+
+// Within filters items whose field is within d before now
+func Within(field string, d time.Duration) Query {
+	return None().Within(field, d)
+}
+
+// Within filters items whose field is within d before now
+func (q Query) Within(field string, d time.Duration) Query {
+	return q.Gte(field, reldate.Offset(-d))
+}
+
+// OlderThan filters items whose field is more than d before now
+func OlderThan(field string, d time.Duration) Query {
+	return None().OlderThan(field, d)
+}
+
+// OlderThan filters items whose field is more than d before now
+func (q Query) OlderThan(field string, d time.Duration) Query {
+	return q.Lt(field, reldate.Offset(-d))
+}
@@ -0,0 +1,59 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeDates(t *testing.T) {
+	assert.Equal(t, "$NOW(-30 days)", NowOffset(-30*24*time.Hour))
+
+	q := Within("date_created", 30*24*time.Hour).OlderThan("date_updated", 2*time.Hour)
+	assert.Equal(t, map[string]string{
+		"limit":                      "-1",
+		"filter[date_created][_gte]": "$NOW(-30 days)",
+		"filter[date_updated][_lt]":  "$NOW(-2 hours)",
+	}, q.asKeyValue(V9))
+
+	v8 := q.asKeyValue(V8)
+	created, err := time.Parse(datetimeFormat, v8["filter[date_created][gte]"])
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), created, 2*time.Second)
+}
+
+func TestDynamicVariables(t *testing.T) {
+	q := Eq("owner", CurrentUser).Eq("team", CurrentUser+".team").Neq("role", CurrentRole).
+		Gte("date_created", "$NOW(-1 year)").Lt("publish_on", "$NOW(+2 weeks)")
+	assert.Equal(t, map[string]string{
+		"limit":                      "-1",
+		"filter[owner][_eq]":         "$CURRENT_USER",
+		"filter[team][_eq]":          "$CURRENT_USER.team",
+		"filter[role][_neq]":         "$CURRENT_ROLE",
+		"filter[date_created][_gte]": "$NOW(-1 year)",
+		"filter[publish_on][_lt]":    "$NOW(+2 weeks)",
+	}, q.asKeyValue(V9))
+
+	v8 := Gte("date_created", "$NOW(-1 year)").asKeyValue(V8)
+	created, err := time.Parse(datetimeFormat, v8["filter[date_created][gte]"])
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(-1, 0, 0), created, 2*time.Second)
+}
+
+func TestRelativeDatesClockV8(t *testing.T) {
+	var created string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created = r.URL.Query().Get("filter[date_created][gte]")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	api.Clock = NewFakeClock(time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC))
+
+	_, err := api.Items(context.Background(), Within("date_created", 24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "2022-05-04 10:00:00", created)
+	assert.Equal(t, api.ItemsURL(Within("date_created", 24*time.Hour)), api.ItemsURL(Gte("date_created", "2022-05-04 10:00:00")))
+}
@@ -0,0 +1,78 @@
+// Package reldate builds and resolves relative dates of Directus filters like $NOW(-7 days),
+// servers without their support like Directus v8 get them resolved by the client.
+package reldate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Now is a filter value resolved by the server to its current time
+const Now = "$NOW"
+
+// relative time units supported by Directus, from the largest
+var offsetUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"days", 24 * time.Hour},
+	{"hours", time.Hour},
+	{"minutes", time.Minute},
+	{"seconds", time.Second},
+}
+
+// calendar units of relative dates, they are resolved by dates instead of durations
+var calendarUnits = map[string]func(t time.Time, n int) time.Time{
+	"weeks":  func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+	"months": func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) },
+	"years":  func(t time.Time, n int) time.Time { return t.AddDate(n, 0, 0) },
+}
+
+// Offset returns a relative date of the current time shifted by d,
+// e.g. $NOW(-30 days) for -30*24*time.Hour. Durations are truncated to seconds.
+func Offset(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	if d == 0 {
+		return Now
+	}
+	for _, u := range offsetUnits {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%s(%d %s)", Now, d/u.d, u.name)
+		}
+	}
+	return Now
+}
+
+// Resolve resolves $NOW and relative dates like $NOW(-7 days) or $NOW(+1 year) to a time relative to now,
+// it reports false for other values
+func Resolve(v string, now time.Time) (time.Time, bool) {
+	if v == Now {
+		return now, true
+	}
+	if !strings.HasPrefix(v, Now+"(") || !strings.HasSuffix(v, ")") {
+		return time.Time{}, false
+	}
+	n, unit, ok := strings.Cut(strings.TrimSpace(v[len(Now)+1:len(v)-1]), " ")
+	if !ok {
+		return time.Time{}, false
+	}
+	count, err := strconv.ParseInt(n, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	unit = strings.TrimSpace(unit)
+	if !strings.HasSuffix(unit, "s") {
+		unit += "s"
+	}
+	for _, u := range offsetUnits {
+		if u.name == unit {
+			return now.Add(time.Duration(count) * u.d), true
+		}
+	}
+	if add, ok := calendarUnits[unit]; ok {
+		return add(now, int(count)), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,36 @@
+package reldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffset(t *testing.T) {
+	assert.Equal(t, "$NOW(-30 days)", Offset(-30*24*time.Hour))
+	assert.Equal(t, "$NOW(36 hours)", Offset(36*time.Hour))
+	assert.Equal(t, "$NOW(-90 seconds)", Offset(-90*time.Second))
+	assert.Equal(t, "$NOW", Offset(time.Millisecond))
+}
+
+func TestResolve(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	for v, want := range map[string]time.Time{
+		"$NOW":              now,
+		"$NOW(-7 days)":     now.AddDate(0, 0, -7),
+		"$NOW(+1 day)":      now.AddDate(0, 0, 1),
+		"$NOW(-2 weeks)":    now.AddDate(0, 0, -14),
+		"$NOW(-1 month)":    now.AddDate(0, -1, 0),
+		"$NOW(1 year)":      now.AddDate(1, 0, 0),
+		"$NOW(-90 minutes)": now.Add(-90 * time.Minute),
+	} {
+		got, ok := Resolve(v, now)
+		assert.True(t, ok, v)
+		assert.Equal(t, want, got, v)
+	}
+	_, ok := Resolve("$NOW(-1 fortnight)", now)
+	assert.False(t, ok)
+	_, ok = Resolve("$CURRENT_USER", now)
+	assert.False(t, ok)
+}
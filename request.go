@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -23,72 +24,342 @@ type request struct {
 	body   any
 }
 
+// rawBody is a request body which isn't JSON, e.g. a multipart upload
+type rawBody struct {
+	contentType string
+	data        []byte
+}
+
 func (a *API[R, W, PK]) executeRequest(r request, expectedStatus int, dest any) error {
 	if dest != nil && reflect.ValueOf(dest).Kind() != reflect.Ptr {
 		return fmt.Errorf("dest has to be a pointer")
 	}
 
-	var b io.Reader
-	if r.body != nil {
-		bodyBytes, err := json.Marshal(r.body)
+	if err := a.Guard.check(r.method, a.baseURL(), r.url); err != nil {
+		return a.operationError(r, 0, 0, err)
+	}
+
+	if err := a.Lifecycle.acquire(); err != nil {
+		return a.operationError(r, 0, 0, err)
+	}
+	defer a.Lifecycle.release()
+
+	if a.ServerInfo != nil && r.url != a.baseURL()+"/server/info" {
+		a.loadServerInfo(r.ctx)
+	}
+
+	body, err := a.encodeBody(r.body)
+	if err != nil {
+		return a.operationError(r, 0, 0, err)
+	}
+
+	start := a.clock().Now()
+	for attempt := 1; ; attempt++ {
+		if d := a.RateLimiter.delayBelow(a.clock().Now(), a.LiveConfig.Config().RateLimitThreshold); d > 0 {
+			if err := a.sleep(r.ctx, d); err != nil {
+				return a.operationError(r, attempt, a.clock().Now().Sub(start), fmt.Errorf("wait for rate limit: %w", err))
+			}
+		}
+		req, resp, err := a.attemptRequest(r, body, expectedStatus, dest)
+		if err == nil {
+			if err := a.postProcess(r.ctx, dest); err != nil {
+				return a.operationError(r, attempt, a.clock().Now().Sub(start), err)
+			}
+			return nil
+		}
+		if !a.shouldRetry(attempt, req, resp, err) {
+			return a.operationError(r, attempt, a.clock().Now().Sub(start), err)
+		}
+		if err := a.sleep(r.ctx, a.retryDelay(attempt, resp)); err != nil {
+			return a.operationError(r, attempt, a.clock().Now().Sub(start), fmt.Errorf("wait for retry: %w", err))
+		}
+	}
+}
+
+// encodedBody is a request body encoded once and replayed by every attempt
+type encodedBody struct {
+	contentType string
+	data        []byte
+	// stream is sent instead of data, it's rewound to start before every attempt
+	stream io.ReadSeeker
+	start  int64
+	size   int64
+}
+
+// encodeBody encodes the request body for all attempts. Readers are sent as they are,
+// readers which can't be rewound by io.Seeker are buffered so retries can replay them.
+func (a *API[R, W, PK]) encodeBody(body any) (*encodedBody, error) {
+	b := &encodedBody{contentType: "application/json"}
+	switch v := body.(type) {
+	case nil:
+		return b, nil
+	case json.RawMessage:
+		// already encoded by a registered codec
+		b.data = v
+	case rawBody:
+		b.contentType, b.data = v.contentType, v.data
+	case io.ReadSeeker:
+		start, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("seek request body: %w", err)
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("seek request body: %w", err)
+		}
+		b.stream, b.start, b.size = v, start, end-start
+	case io.Reader:
+		data, err := io.ReadAll(v)
 		if err != nil {
-			return fmt.Errorf("marshal request body: %w", err)
+			return nil, fmt.Errorf("read request body: %w", err)
 		}
-		b = bytes.NewBuffer(bodyBytes)
+		b.data = data
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request body: %w", err)
+		}
+		b.data = data
+	}
+	if b.stream == nil {
+		b.size = int64(len(b.data))
+	}
+	if err := a.checkPayloadSize(b.size); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// reader returns the body for a new attempt
+func (b *encodedBody) reader() (io.ReadCloser, error) {
+	if b.stream != nil {
+		if _, err := b.stream.Seek(b.start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("rewind request body: %w", err)
+		}
+		return io.NopCloser(b.stream), nil
 	}
+	return io.NopCloser(bytes.NewReader(b.data)), nil
+}
 
+// attemptRequest executes a single attempt of the request,
+// it returns the request and response for the retry policy
+func (a *API[R, W, PK]) attemptRequest(r request, body *encodedBody, expectedStatus int, dest any) (*http.Request, *http.Response, error) {
 	req, err := http.NewRequestWithContext(
 		r.ctx,
 		r.method,
 		r.url,
-		b,
+		nil,
 	)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
-
-	queryValues := url.Values{}
-
-	for k, v := range r.qv {
-		queryValues.Set(k, v)
+	if body.size > 0 {
+		if req.Body, err = body.reader(); err != nil {
+			return nil, nil, err
+		}
+		req.ContentLength = body.size
+		req.GetBody = body.reader
 	}
 
-	req.URL.RawQuery = queryValues.Encode()
+	req.URL.RawQuery = encodeQuery(r.qv)
 
-	req.Header.Set("Authorization", "Bearer "+a.BearerToken)
-	req.Header.Set("Content-Type", "application/json")
+	token, err := a.bearerToken(r.ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != "" {
+		// without a token requests are public or authenticated by a session cookie
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", body.contentType)
+	for k, v := range requestHeaders(r.ctx) {
+		req.Header.Set(k, v)
+	}
 
-	if a.debug {
-		reqDump, _ := httputil.DumpRequestOut(req, true)
-		fmt.Println("--- Request start ---")
-		fmt.Println(string(reqDump))
-		fmt.Println("--- Request end ---")
+	var reqDump []byte
+	debug := a.debugRequest(r.method)
+	if debug {
+		// the request is printed together with the response, status filters need it
+		reqDump, _ = httputil.DumpRequestOut(req, true)
 	}
 
-	resp, err := a.HTTPClient.Do(req)
+	resp, err := a.httpClient().Do(req)
 	if err != nil {
-		return fmt.Errorf("execute request: %v", err)
+		if debug {
+			a.printDump("Request", reqDump)
+		}
+		return req, nil, fmt.Errorf("execute request: %w", contextErr(r.ctx, err))
+	}
+	defer drainAndClose(resp.Body)
+	a.RateLimiter.update(resp.Header, a.clock().Now())
+	if fn, ok := r.ctx.Value(responseHeadersCtxKey{}).(func(http.Header)); ok {
+		fn(resp.Header)
 	}
-	defer resp.Body.Close()
 
-	if a.debug {
+	if debug && a.debugStatus(resp.StatusCode) {
 		respDump, _ := httputil.DumpResponse(resp, true)
-		fmt.Println("--- Response start ---")
-		fmt.Println(string(respDump))
-		fmt.Println("--- Response end ---")
+		a.printDump("Request", reqDump)
+		a.printDump("Response", respDump)
 	}
 
 	if resp.StatusCode != expectedStatus {
 		respBytes, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status %s: %s", resp.Status, string(respBytes))
+		return req, resp, a.saveArtifact(req, body.data, resp, respBytes, newResponseError(resp.StatusCode, resp.Status, respBytes))
 	}
 
-	if dest != nil {
+	if buf, ok := dest.(*bytes.Buffer); ok {
+		// raw content like file assets, a failed attempt leaves nothing behind
+		buf.Reset()
+		if _, err := io.Copy(buf, resp.Body); err != nil {
+			buf.Reset()
+			return req, resp, fmt.Errorf("read response: %w", contextErr(r.ctx, err))
+		}
+	} else if stream, ok := dest.(*streamDest); ok {
+		n, err := io.Copy(stream.w, resp.Body)
+		if err != nil && n > 0 {
+			// bytes written to the stream can't be taken back, so the request isn't retried
+			return nil, resp, fmt.Errorf("read response: %w", contextErr(r.ctx, err))
+		}
+		if err != nil {
+			return req, resp, fmt.Errorf("read response: %w", contextErr(r.ctx, err))
+		}
+	} else if dest != nil && (a.Artifacts != nil || a.StrictResponses) {
+		// the body is kept for the artifact of a decode failure and for errors of strict responses
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return req, resp, fmt.Errorf("read response: %w", contextErr(r.ctx, err))
+		}
+		if a.StrictResponses && len(parseErrorDetails(respBytes)) > 0 {
+			return req, resp, a.saveArtifact(req, body.data, resp, respBytes, newResponseError(resp.StatusCode, resp.Status, respBytes))
+		}
+		if err := json.Unmarshal(respBytes, dest); err != nil {
+			return req, resp, a.saveArtifact(req, body.data, resp, respBytes, fmt.Errorf("decoding json response: %w", truncatedErr(respBytes, err)))
+		}
+	} else if dest != nil {
 		err = json.NewDecoder(resp.Body).Decode(dest)
 		if err != nil {
-			return fmt.Errorf("decoding json response: %w", err)
+			return req, resp, fmt.Errorf("decoding json response: %w", contextErr(r.ctx, err))
 		}
 	}
 
+	return req, resp, nil
+}
+
+type headersCtxKey struct{}
+
+// withHeaders returns a context adding headers to requests executed with it
+func withHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := map[string]string{}
+	for k, v := range requestHeaders(ctx) {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, headersCtxKey{}, merged)
+}
+
+func requestHeaders(ctx context.Context) map[string]string {
+	h, _ := ctx.Value(headersCtxKey{}).(map[string]string)
+	return h
+}
+
+type responseHeadersCtxKey struct{}
+
+// WithResponseHeaders returns a context passing headers of every response to requests executed with it to fn,
+// e.g. RateLimit-Remaining or headers of a proxy. Failed and retried attempts are passed as well,
+// the last call receives headers of the final response.
+func WithResponseHeaders(ctx context.Context, fn func(http.Header)) context.Context {
+	return context.WithValue(ctx, responseHeadersCtxKey{}, fn)
+}
+
+// truncatedErr reports JSON which ended prematurely as io.ErrUnexpectedEOF,
+// the same error is returned by the streaming decoder for truncated bodies
+func truncatedErr(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(data)) {
+		return fmt.Errorf("%w: %v", io.ErrUnexpectedEOF, err)
+	}
+	return err
+}
+
+// maxDrainBytes limits how much of an unread response body is drained,
+// bigger leftovers are cheaper to drop together with the connection
+const maxDrainBytes = 4 << 10
+
+// drainAndClose reads the rest of the body so the connection can be reused and closes it.
+// Reads of a canceled request's body fail right away, so it never blocks on cancellation.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	_ = body.Close()
+}
+
+// contextErr returns the context error when ctx is done, so callers can match
+// context.Canceled and context.DeadlineExceeded instead of transport specific errors
+func contextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// checkPayloadSize reports bodies bigger than MaxPayloadSize or the limit advertised by the server,
+// it fails only with StrictPayloadSize
+func (a *API[R, W, PK]) checkPayloadSize(size int64) error {
+	limit := a.maxPayloadSize()
+	if limit <= 0 || size <= limit {
+		return nil
+	}
+	err := &PayloadSizeError{size, limit}
+	if a.StrictPayloadSize {
+		return err
+	}
+	a.warn(err)
 	return nil
 }
+
+func (a *API[R, W, PK]) warn(err error) {
+	if a.OnWarning != nil {
+		a.OnWarning(err)
+	}
+}
+
+func (a *API[R, W, PK]) bearerToken(ctx context.Context) (string, error) {
+	if token, ok := ctx.Value(tokenCtxKey{}).(string); ok {
+		return token, nil
+	}
+	if token := a.LiveConfig.Config().Token; token != "" && !a.fixedToken {
+		return token, nil
+	}
+	var p TokenProvider = StaticToken(a.BearerToken)
+	switch {
+	case a.TokenProvider != nil:
+		p = a.TokenProvider
+	case a.Auth != nil:
+		p = a.Auth
+	case a.TokenRefresher != nil:
+		p = a.TokenRefresher
+	}
+	token, err := p.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get bearer token: %w", err)
+	}
+	return token, nil
+}
+
+// httpClient returns HTTPClient or http.DefaultClient when it's not set,
+// the default client uses fetch when compiled to js/wasm
+func (a *API[R, W, PK]) httpClient() *http.Client {
+	if a.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return a.HTTPClient
+}
+
+func encodeQuery(qv map[string]string) string {
+	queryValues := url.Values{}
+	for k, v := range qv {
+		queryValues.Set(k, v)
+	}
+	return queryValues.Encode()
+}
@@ -0,0 +1,182 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPI(t *testing.T, h http.Handler) API[FruitR, FruitW, int] {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return API[FruitR, FruitW, int]{
+		Scheme:         "http",
+		Host:           strings.TrimPrefix(srv.URL, "http://"),
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient:     srv.Client(),
+	}
+}
+
+func TestCancelMidDecode(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":[{"id":1,"name":"kiwi"},`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := api.Items(ctx, None())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled), err.Error())
+}
+
+func TestCanceledBeforeRequest(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := api.GetByID(ctx, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled), err.Error())
+}
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestResponseBodyClosed(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"ok", http.StatusOK, `{"data":{"id":1}} trailing garbage`},
+		{"unexpected status", http.StatusForbidden, `{"errors":[]}`},
+		{"invalid json", http.StatusOK, `{"data":`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &trackedBody{Reader: strings.NewReader(tc.body)}
+			api := API[FruitR, FruitW, int]{
+				Scheme:         "http",
+				Host:           "localhost:8080",
+				CollectionName: "fruits",
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					resp := jsonResponse(tc.status, "")
+					resp.Body = body
+					return resp, nil
+				})},
+			}
+			_, _ = api.GetByID(context.Background(), 1)
+			assert.True(t, body.closed)
+		})
+	}
+}
+
+func TestPayloadSizeGuard(t *testing.T) {
+	var calls int
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.MaxPayloadSize = 64
+	var warnings []error
+	api.OnWarning = func(err error) {
+		warnings = append(warnings, err)
+	}
+	big := map[string]any{"name": strings.Repeat("x", 100)}
+
+	_, err := api.Create(context.Background(), map[string]any{"name": "kiwi"})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	_, err = api.Create(context.Background(), big)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	var sizeErr *PayloadSizeError
+	require.True(t, errors.As(warnings[0], &sizeErr))
+	assert.Equal(t, int64(64), sizeErr.Limit)
+
+	api.StrictPayloadSize = true
+	_, err = api.Create(context.Background(), big)
+	require.True(t, errors.As(err, &sizeErr))
+	assert.Equal(t, 2, calls)
+}
+
+func TestWithResponseHeaders(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "41")
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+
+	var remaining string
+	ctx := WithResponseHeaders(context.Background(), func(h http.Header) {
+		remaining = h.Get("RateLimit-Remaining")
+	})
+	_, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "41", remaining)
+}
+
+// countedJSON counts how many times it's marshaled
+type countedJSON struct {
+	calls *int32
+}
+
+func (c countedJSON) MarshalJSON() ([]byte, error) {
+	atomic.AddInt32(c.calls, 1)
+	return []byte(`"kiwi"`), nil
+}
+
+func TestBodyReplayedOnRetry(t *testing.T) {
+	var bodies []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	api.MaxRetries = 1
+	api.RetryBackoff = time.Millisecond
+
+	var marshals int32
+	_, err := api.Update(context.Background(), 1, map[string]any{"name": countedJSON{&marshals}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"name":"kiwi"}`, `{"name":"kiwi"}`}, bodies)
+	assert.EqualValues(t, 1, marshals, "the body should be encoded once")
+
+	echo := RegisterEndpoint[io.Reader, json.RawMessage](api, http.MethodPut, "/echo")
+	for name, body := range map[string]io.Reader{
+		"seeker":     strings.NewReader(`{"name":"kiwi"}`),
+		"not seeker": io.MultiReader(strings.NewReader(`{"name":`), strings.NewReader(`"kiwi"}`)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			bodies = nil
+			_, err := echo(context.Background(), body)
+			require.NoError(t, err)
+			assert.Equal(t, []string{`{"name":"kiwi"}`, `{"name":"kiwi"}`}, bodies)
+		})
+	}
+}
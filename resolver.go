@@ -0,0 +1,91 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Resolver stitches items of other collections referenced by foreign keys into parent items P.
+// References are fetched in batches by their primary keys, so resolving a page of items
+// takes one request per batch of every registered reference instead of a GetByID per item.
+type Resolver[P any] struct {
+	// BatchSize is a maximum number of keys per request, defaults to 100
+	BatchSize int
+	refs      []func(ctx context.Context, items []P, batchSize int) error
+}
+
+// Reference describes how parent items P reference items R of another collection
+type Reference[P, R any, PK PrimaryKey] struct {
+	// PrimaryKey is the primary key field of the referenced collection, defaults to id
+	PrimaryKey string
+	// Key returns the foreign key of a parent item, false when it references nothing
+	Key func(P) (PK, bool)
+	// ID returns the primary key of a referenced item
+	ID func(R) PK
+	// Set stitches the referenced item into its parent
+	Set func(*P, R)
+}
+
+// Register adds a reference resolved by api to the resolver
+func Register[P, R, W any, PK PrimaryKey](r *Resolver[P], api API[R, W, PK], ref Reference[P, R, PK]) {
+	pkField := ref.PrimaryKey
+	if pkField == "" {
+		pkField = "id"
+	}
+	r.refs = append(r.refs, func(ctx context.Context, items []P, batchSize int) error {
+		var keys []string
+		seen := map[PK]bool{}
+		for _, item := range items {
+			if k, ok := ref.Key(item); ok && !seen[k] {
+				seen[k] = true
+				key, err := formatPK(k)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, EscapeFilterValue(key))
+			}
+		}
+
+		resolved := make(map[PK]R, len(keys))
+		for start := 0; start < len(keys); start += batchSize {
+			end := start + batchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			batch, err := api.Items(ctx, In(pkField, strings.Join(keys[start:end], ",")).Limit(end-start))
+			if err != nil {
+				return fmt.Errorf("resolve %s: %w", api.CollectionName, err)
+			}
+			for _, ri := range batch {
+				resolved[ref.ID(ri)] = ri
+			}
+		}
+
+		for i := range items {
+			k, ok := ref.Key(items[i])
+			if !ok {
+				continue
+			}
+			if ri, ok := resolved[k]; ok {
+				ref.Set(&items[i], ri)
+			}
+		}
+		return nil
+	})
+}
+
+// Resolve fetches references of all registered collections and stitches them into items,
+// references to items which don't exist or aren't readable are left untouched
+func (r *Resolver[P]) Resolve(ctx context.Context, items []P) error {
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultChunkSize
+	}
+	for _, ref := range r.refs {
+		if err := ref(ctx, items, batchSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID      int
+	FruitID int
+	Fruit   FruitR
+}
+
+func TestResolver(t *testing.T) {
+	var requests int
+	fruits := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		qv := r.URL.Query()
+		assert.Equal(t, "/_/items/fruits", r.URL.Path)
+		switch qv.Get("filter[id][in]") {
+		case "1,2":
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"kiwi"},{"id":2,"name":"lime"}]}`))
+		case "3":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			t.Errorf("unexpected filter %q", qv.Get("filter[id][in]"))
+		}
+	}))
+
+	r := &Resolver[order]{BatchSize: 2}
+	Register(r, fruits, Reference[order, FruitR, int]{
+		Key: func(o order) (int, bool) {
+			return o.FruitID, o.FruitID != 0
+		},
+		ID: func(f FruitR) int {
+			return f.ID
+		},
+		Set: func(o *order, f FruitR) {
+			o.Fruit = f
+		},
+	})
+
+	orders := []order{{1, 1, FruitR{}}, {2, 2, FruitR{}}, {3, 1, FruitR{}}, {4, 3, FruitR{}}, {5, 0, FruitR{}}}
+	require.NoError(t, r.Resolve(context.Background(), orders))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, "kiwi", orders[0].Fruit.Name)
+	assert.Equal(t, "lime", orders[1].Fruit.Name)
+	assert.Equal(t, "kiwi", orders[2].Fruit.Name)
+	assert.Zero(t, orders[3].Fruit.ID)
+	assert.Zero(t, orders[4].Fruit.ID)
+}
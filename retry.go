@@ -0,0 +1,117 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryDecision uint8
+
+const (
+	DontRetry RetryDecision = iota
+	Retry
+)
+
+// RetryPolicy decides whether a failed request is retried. resp is nil when the request
+// failed in transport, err is a *ResponseError when Directus responded with an unexpected status.
+type RetryPolicy interface {
+	Decide(req *http.Request, resp *http.Response, err error) RetryDecision
+}
+
+// RetryPolicyFunc adapts a function to RetryPolicy
+type RetryPolicyFunc func(req *http.Request, resp *http.Response, err error) RetryDecision
+
+func (f RetryPolicyFunc) Decide(req *http.Request, resp *http.Response, err error) RetryDecision {
+	return f(req, resp, err)
+}
+
+// DefaultRetryPolicy retries requests rejected by the rate limiter or an unavailable server.
+// Transport errors, gateway errors and truncated responses are retried only for idempotent methods
+// GET, HEAD, PUT and DELETE, because a create or update request may have been processed already,
+// e.g. PATCH with nested creates of related items would create them twice.
+var DefaultRetryPolicy RetryPolicy = RetryPolicyFunc(defaultRetryDecision)
+
+func defaultRetryDecision(req *http.Request, resp *http.Response, err error) RetryDecision {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return DontRetry
+	}
+	idempotent := false
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		idempotent = true
+	}
+	if resp == nil || errors.Is(err, io.ErrUnexpectedEOF) {
+		// the response was cut off, e.g. by a misbehaving proxy
+		if idempotent {
+			return Retry
+		}
+		return DontRetry
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return Retry
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if idempotent {
+			return Retry
+		}
+	}
+	return DontRetry
+}
+
+// RetryCodes extends policy to retry also responses with any of the given Directus error codes,
+// e.g. FORBIDDEN in deployments where permissions are cached lazily
+func RetryCodes(policy RetryPolicy, codes ...string) RetryPolicy {
+	return RetryPolicyFunc(func(req *http.Request, resp *http.Response, err error) RetryDecision {
+		var respErr *ResponseError
+		if errors.As(err, &respErr) {
+			for _, c := range codes {
+				if respErr.HasCode(c) {
+					return Retry
+				}
+			}
+		}
+		return policy.Decide(req, resp, err)
+	})
+}
+
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// retryDelay returns exponential backoff with jitter for the given attempt starting at 1,
+// Retry-After header of the response takes precedence
+func (a *API[R, W, PK]) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second
+		}
+	}
+	base := orDefault(a.RetryBackoff, defaultRetryBackoff)
+	delay := base << (attempt - 1)
+	return delay + time.Duration(a.rand().Int63n(int64(base)))
+}
+
+func (a *API[R, W, PK]) shouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool {
+	if attempt > a.MaxRetries || req == nil {
+		return false
+	}
+	policy := a.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+	return policy.Decide(req, resp, err) == Retry
+}
+
+// sleep waits for d on the API's clock, it returns ctx error when ctx is done first
+func (a *API[R, W, PK]) sleep(ctx context.Context, d time.Duration) error {
+	t := a.clock().NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,115 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	forbidden := `{"errors":[{"message":"You don't have permission to access this.","extensions":{"code":"FORBIDDEN"}}]}`
+
+	t.Run("custom codes", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(forbidden))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+		}))
+		api.MaxRetries = 3
+		api.RetryBackoff = time.Millisecond
+		api.RetryPolicy = RetryCodes(DefaultRetryPolicy, "FORBIDDEN")
+
+		fruit, err := api.GetByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "kiwi", fruit.Name)
+		assert.EqualValues(t, 3, calls)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(forbidden))
+		}))
+		api.MaxRetries = 2
+		api.RetryBackoff = time.Millisecond
+		api.RetryPolicy = RetryCodes(DefaultRetryPolicy, "FORBIDDEN")
+
+		_, err := api.GetByID(context.Background(), 1)
+		var respErr *ResponseError
+		require.True(t, errors.As(err, &respErr))
+		assert.Equal(t, http.StatusForbidden, respErr.StatusCode)
+		assert.Equal(t, []ErrorDetail{{"FORBIDDEN", "You don't have permission to access this."}}, respErr.Errors)
+		assert.EqualValues(t, 3, calls)
+	})
+
+	t.Run("default policy", func(t *testing.T) {
+		var calls int32
+		api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		api.MaxRetries = 2
+		api.RetryBackoff = time.Millisecond
+
+		_, err := api.Create(context.Background(), map[string]any{"name": "kiwi"})
+		require.Error(t, err)
+		assert.EqualValues(t, 1, calls, "create should not be retried on bad gateway")
+
+		_, err = api.Update(context.Background(), 1, map[string]any{"name": "kiwi"})
+		require.Error(t, err)
+		assert.EqualValues(t, 2, calls, "update should not be retried on bad gateway")
+
+		_, err = api.GetByID(context.Background(), 1)
+		require.Error(t, err)
+		assert.EqualValues(t, 5, calls)
+	})
+}
+
+func TestRetryTruncatedResponse(t *testing.T) {
+	var calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// a proxy cutting off the body after the headers were sent
+			_, _ = w.Write([]byte(`{"data":{"id":1,"na`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+	}))
+	api.MaxRetries = 1
+	api.RetryBackoff = time.Millisecond
+
+	fruit, err := api.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "kiwi", fruit.Name)
+	assert.EqualValues(t, 2, calls)
+
+	t.Run("not idempotent", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		_, err := api.Create(context.Background(), map[string]any{"name": "kiwi"})
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.EqualValues(t, 1, calls)
+	})
+
+	t.Run("artifacts", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		api := api
+		api.Artifacts = &ArtifactStore{Dir: t.TempDir()}
+		fruit, err := api.GetByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "kiwi", fruit.Name)
+		assert.EqualValues(t, 2, calls)
+	})
+}
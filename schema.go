@@ -0,0 +1,155 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaSnapshot retrieves the schema of the whole Directus instance as a raw JSON.
+// Directus v8 has no snapshot endpoint, collections including their fields are returned instead.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/schema.html#retrieve-schema-snapshot
+// https://v8.docs.directus.io/api/collections.html#list-collections
+func (d API[R, W, PK]) SchemaSnapshot(ctx context.Context) (json.RawMessage, error) {
+	u := d.baseURL() + "/schema/snapshot"
+	if d.Version == V8 {
+		u = d.baseURL() + "/collections"
+	}
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		nil,
+		nil,
+	}
+	var respBody struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute schema snapshot request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// FieldSchema is metadata of a collection field, Type is a Directus type
+// like integer, uuid or timestamp
+type FieldSchema struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+}
+
+// CollectionSchema holds fields of a collection, when it's set to API.Schema
+// filter values of queries are validated before they are sent to the server
+type CollectionSchema struct {
+	Collection string
+	Fields     map[string]FieldSchema
+}
+
+// LoadSchema retrieves fields metadata of the collection
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/fields.html#list-fields-in-collection
+// https://v8.docs.directus.io/api/fields.html#list-fields-in-collection
+func (d API[R, W, PK]) LoadSchema(ctx context.Context) (*CollectionSchema, error) {
+	var respBody struct {
+		Data []FieldSchema `json:"data"`
+	}
+	err := d.getMetadata(ctx, fmt.Sprintf("%s/fields/%s", d.baseURL(), d.CollectionName), &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute fields request: %w", err)
+	}
+	schema := &CollectionSchema{
+		Collection: d.CollectionName,
+		Fields:     make(map[string]FieldSchema, len(respBody.Data)),
+	}
+	for _, f := range respBody.Data {
+		schema.Fields[f.Field] = f
+	}
+	return schema, nil
+}
+
+// FilterValueError is returned when a filter value doesn't fit the type of the filtered field
+type FilterValueError struct {
+	Field string
+	Type  string
+	Value string
+}
+
+func (e *FilterValueError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("filter on unknown field %q", e.Field)
+	}
+	return fmt.Sprintf("filter value %q is not valid for field %q of type %s", e.Value, e.Field, e.Type)
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validate checks filter values of q against types of the fields,
+// fields of related collections and dynamic variables like $NOW are not checked,
+// only the relational field of the collection of a path like author.name has to exist
+func (s *CollectionSchema) validate(q Query) error {
+	var err error
+	q.eachFilterValue(func(field, value string) {
+		if err != nil {
+			return
+		}
+		if path := splitPath(field); len(path) > 1 {
+			if _, ok := s.Fields[path[0]]; !ok {
+				err = &FilterValueError{Field: field}
+			}
+			return
+		}
+		name, fieldType := field, ""
+		if _, inner, ok := splitFieldFunction(field); ok {
+			// functions return numbers regardless of the type of their field
+			name, fieldType = inner, "integer"
+		}
+		f, ok := s.Fields[name]
+		if !ok {
+			err = &FilterValueError{Field: field}
+			return
+		}
+		if fieldType == "" {
+			fieldType = f.Type
+		}
+		if !validFieldValue(strings.ToLower(fieldType), value) {
+			err = &FilterValueError{field, fieldType, value}
+		}
+	})
+	return err
+}
+
+func validFieldValue(fieldType, value string) bool {
+	if strings.HasPrefix(value, "$") {
+		return true
+	}
+	var err error
+	switch fieldType {
+	case "integer", "biginteger":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float", "decimal":
+		_, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	case "uuid":
+		return uuidRegexp.MatchString(value)
+	case "timestamp", "datetime", "date":
+		if value == "now" {
+			return true
+		}
+		_, err = parseTimestamp(value)
+		if err != nil {
+			_, err = time.Parse("2006-01-02", value)
+		}
+	}
+	return err == nil
+}
@@ -0,0 +1,57 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidation(t *testing.T) {
+	requests := 0
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/fields/fruits" {
+			_, _ = w.Write([]byte(`{"data":[
+				{"field":"id","type":"integer"},
+				{"field":"owner","type":"uuid"},
+				{"field":"discovered_at","type":"timestamp"},
+				{"field":"enabled","type":"boolean"}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	api.Namespace = ""
+	api.Version = V9
+
+	schema, err := api.LoadSchema(context.Background())
+	require.NoError(t, err)
+	api.Schema = schema
+
+	var valueErr *FilterValueError
+	_, err = api.Items(context.Background(), Eq("owner", "42"))
+	require.True(t, errors.As(err, &valueErr))
+	assert.Equal(t, &FilterValueError{"owner", "uuid", "42"}, valueErr)
+
+	_, err = api.Items(context.Background(), In("id", "1,2,x"))
+	require.True(t, errors.As(err, &valueErr))
+	assert.Equal(t, "x", valueErr.Value)
+
+	_, err = api.Items(context.Background(), Eq("color", "red"))
+	require.True(t, errors.As(err, &valueErr))
+	assert.Equal(t, `filter on unknown field "color"`, err.Error())
+	_, err = api.Items(context.Background(), Eq("author.name", "Jane"))
+	assert.Equal(t, `filter on unknown field "author.name"`, err.Error())
+	assert.Equal(t, 1, requests, "invalid queries should not reach the server")
+
+	_, err = api.Items(context.Background(), Eq("owner", "1b7c2b8e-4c5f-4a1b-9c9d-2f6f5a3e8d10").
+		Lte("discovered_at", Now).
+		Or(Eq("enabled", "true"), Gt("id", "10")).
+		Eq("owner.name", "anything"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,95 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// SearchResult is an item found by GlobalSearch, Item is the read model of the collection's API
+type SearchResult struct {
+	Collection string
+	Item       any
+	// Rank is a relevance of the item, exact matches of string fields rank above prefixes and substrings
+	Rank int
+}
+
+// SearchCollection is a collection searched by GlobalSearch, see Searchable
+type SearchCollection struct {
+	add func(m *MultiQuery, q Query) func() []SearchResult
+}
+
+// Searchable makes the collection of api searchable by GlobalSearch
+func Searchable[R, W any, PK PrimaryKey](api API[R, W, PK]) SearchCollection {
+	return SearchCollection{func(m *MultiQuery, q Query) func() []SearchResult {
+		var items []R
+		AddQuery(m, api, q, &items)
+		return func() []SearchResult {
+			out := make([]SearchResult, len(items))
+			for i, item := range items {
+				out[i] = SearchResult{Collection: api.CollectionName, Item: item}
+			}
+			return out
+		}
+	}}
+}
+
+// GlobalSearch searches collections concurrently by their search parameter, e.g. for site-wide search bars.
+// Results are sorted by rank, results of the same rank keep the order of collections and of the server.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/query.html#search
+// https://v8.docs.directus.io/api/query/search.html
+func GlobalSearch(ctx context.Context, term string, collections ...SearchCollection) ([]SearchResult, error) {
+	var m MultiQuery
+	results := make([]func() []SearchResult, len(collections))
+	for i, c := range collections {
+		results[i] = c.add(&m, Search(term))
+	}
+	if err := m.Run(ctx); err != nil {
+		return nil, err
+	}
+
+	var out []SearchResult
+	for _, r := range results {
+		for _, res := range r() {
+			res.Rank = searchRank(res.Item, term)
+			out = append(out, res)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Rank > out[j].Rank
+	})
+	return out, nil
+}
+
+// searchRank scores string fields of item matching term case-insensitively
+func searchRank(item any, term string) int {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return 0
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return 0
+	}
+	term = strings.ToLower(term)
+	rank := 0
+	for _, v := range fields {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		s = strings.ToLower(s)
+		switch {
+		case s == term:
+			rank += 3
+		case strings.HasPrefix(s, term):
+			rank += 2
+		case strings.Contains(s, term):
+			rank++
+		}
+	}
+	return rank
+}
@@ -0,0 +1,41 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalSearch(t *testing.T) {
+	fruits := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_/items/fruits":
+			assert.Equal(t, "Kiwi", r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"golden kiwi"},{"id":2,"name":"kiwi"}]}`))
+		case "/_/users":
+			assert.Equal(t, "Kiwi", r.URL.Query().Get("search"))
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","email":"kiwi@example.com"}]}`))
+		}
+	}))
+	users := UsersAPI{
+		Scheme:         fruits.Scheme,
+		Host:           fruits.Host,
+		Namespace:      fruits.Namespace,
+		CollectionName: CollectionUsers,
+		HTTPClient:     fruits.HTTPClient,
+		Version:        V9,
+	}
+
+	results, err := GlobalSearch(context.Background(), "Kiwi", Searchable(fruits), Searchable(users))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "fruits", results[0].Collection)
+	assert.Equal(t, "kiwi", results[0].Item.(FruitR).Name)
+	assert.Equal(t, CollectionUsers, results[1].Collection)
+	assert.Equal(t, "kiwi@example.com", results[1].Item.(User).Email)
+	assert.Equal(t, "golden kiwi", results[2].Item.(FruitR).Name)
+	assert.Equal(t, []int{3, 2, 1}, []int{results[0].Rank, results[1].Rank, results[2].Rank})
+}
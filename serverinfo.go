@@ -0,0 +1,129 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// ServerInfoCache retrieves /server/info of a server before the first request of the API and caches it,
+// e.g. the max payload size advertised by the server is used when API.MaxPayloadSize isn't set
+// and a *CompatibilityWarning of a newer server is reported to OnWarning once.
+// Servers are cached by their base url, concurrent lookups of a server share one request.
+// Failures to retrieve the info don't fail requests, servers answering with an error, e.g. to tokens
+// which aren't allowed to see the info, are cached without it. Zero value is ready to use.
+type ServerInfoCache struct {
+	mu      sync.Mutex
+	servers map[string]*serverInfoLookup
+	warned  map[string]bool
+}
+
+// serverInfoLookup retrieves the info of a server, done is closed once info is set
+type serverInfoLookup struct {
+	done chan struct{}
+	info serverInfo
+}
+
+// lookup returns the lookup of a server and whether the caller has to retrieve it
+func (c *ServerInfoCache) lookup(baseURL string) (*serverInfoLookup, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := c.servers[baseURL]; ok {
+		return l, false
+	}
+	if c.servers == nil {
+		c.servers = map[string]*serverInfoLookup{}
+	}
+	l := &serverInfoLookup{done: make(chan struct{})}
+	c.servers[baseURL] = l
+	return l, true
+}
+
+// forget drops a failed lookup, so the next request retrieves the info again
+func (c *ServerInfoCache) forget(baseURL string, l *serverInfoLookup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.servers[baseURL] == l {
+		delete(c.servers, baseURL)
+	}
+}
+
+// warnOnce reports whether a server wasn't warned about yet, every server is warned on its own.
+// Without a cache there's nothing to remember, so every check warns.
+func (c *ServerInfoCache) warnOnce(baseURL string) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.warned[baseURL] {
+		return false
+	}
+	if c.warned == nil {
+		c.warned = map[string]bool{}
+	}
+	c.warned[baseURL] = true
+	return true
+}
+
+// cached returns the info of a server retrieved before, it's empty until the lookup is done
+func (c *ServerInfoCache) cached(baseURL string) serverInfo {
+	if c == nil {
+		return serverInfo{}
+	}
+	c.mu.Lock()
+	l := c.servers[baseURL]
+	c.mu.Unlock()
+	if l == nil {
+		return serverInfo{}
+	}
+	select {
+	case <-l.done:
+		return l.info
+	default:
+		return serverInfo{}
+	}
+}
+
+// loadServerInfo retrieves the info of the server into ServerInfo unless it's cached already
+func (a *API[R, W, PK]) loadServerInfo(ctx context.Context) {
+	if a.ServerInfo == nil {
+		return
+	}
+	baseURL := a.baseURL()
+	l, retrieve := a.ServerInfo.lookup(baseURL)
+	if retrieve {
+		info, err := a.serverInfo(ctx)
+		var respErr *ResponseError
+		if err != nil && !errors.As(err, &respErr) {
+			a.ServerInfo.forget(baseURL, l)
+		}
+		l.info = info
+		close(l.done)
+		a.checkCompatibility(info.version())
+		return
+	}
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+	}
+}
+
+// maxPayloadSize returns MaxPayloadSize or the limit advertised by the server, it's zero when neither is known
+func (a *API[R, W, PK]) maxPayloadSize() int64 {
+	if a.MaxPayloadSize > 0 {
+		return a.MaxPayloadSize
+	}
+	return a.ServerInfo.cached(a.baseURL()).maxPayloadSize()
+}
+
+// maxPayloadSize returns the max size of request bodies in bytes advertised by the server,
+// it's zero when the server doesn't advertise it
+func (i serverInfo) maxPayloadSize() int64 {
+	var size int64
+	if json.Unmarshal(i.MaxPayloadSize, &size) != nil || size < 0 {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,63 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerInfoPayloadSize(t *testing.T) {
+	var infoCalls, calls int
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/server/info" {
+			infoCalls++
+			_, _ = w.Write([]byte(`{"data":{"directus":"8.8.1","maxPayloadSize":64}}`))
+			return
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.ServerInfo = &ServerInfoCache{}
+	api.StrictPayloadSize = true
+	big := map[string]any{"name": strings.Repeat("x", 100)}
+
+	_, err := api.Create(context.Background(), big)
+	var sizeErr *PayloadSizeError
+	require.True(t, errors.As(err, &sizeErr))
+	assert.Equal(t, int64(64), sizeErr.Limit)
+	_, err = api.Create(context.Background(), map[string]any{"name": "kiwi"})
+	require.NoError(t, err)
+
+	// own limit takes precedence over the advertised one
+	api.MaxPayloadSize = 1 << 10
+	_, err = api.Create(context.Background(), big)
+	require.NoError(t, err)
+	assert.Equal(t, 1, infoCalls)
+	assert.Equal(t, 2, calls)
+}
+
+func TestServerInfoForbidden(t *testing.T) {
+	var infoCalls int
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_/server/info" {
+			infoCalls++
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":3,"message":"forbidden"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	api.ServerInfo = &ServerInfoCache{}
+	api.StrictPayloadSize = true
+
+	for i := 0; i < 2; i++ {
+		_, err := api.Create(context.Background(), map[string]any{"name": strings.Repeat("x", 100)})
+		require.NoError(t, err, "the check is disabled without a limit")
+	}
+	assert.Equal(t, 1, infoCalls, "error responses are cached")
+}
@@ -0,0 +1,92 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrStopIteration can be returned by a callback to stop an iteration without an error
+var ErrStopIteration = errors.New("directusapi: stop iteration")
+
+// ItemsShuffled iterates items matching q in a random order without server side random sort.
+// Pages of pageSize items are visited in a shuffled order and items of every page are shuffled,
+// so sampling jobs aren't biased towards the oldest items. q should have a stable sort
+// (e.g. by the primary key) as pages are read by offset. Iteration stops on the first
+// error of fn, ErrStopIteration stops it without an error.
+func (d API[R, W, PK]) ItemsShuffled(ctx context.Context, q Query, pageSize int, fn func(R) error) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	count, err := d.itemsCount(ctx, q)
+	if err != nil {
+		return err
+	}
+	pages := (count + pageSize - 1) / pageSize
+	for _, p := range d.permutation(pages) {
+		items, err := d.Items(ctx, q.Limit(pageSize).Offset(p*pageSize))
+		if err != nil {
+			return err
+		}
+		for _, i := range d.permutation(len(items)) {
+			if err := fn(items[i]); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// itemsCount returns the number of items matching q
+func (d API[R, W, PK]) itemsCount(ctx context.Context, q Query) (int, error) {
+	q, err := d.scopeQuery(ctx, q.withDefaults(d.DefaultQuery))
+	if err != nil {
+		return 0, err
+	}
+	if err := d.supported(q); err != nil {
+		return 0, err
+	}
+	u, qv := d.itemsRequestParams(q.Limit(0))
+	delete(qv, "offset")
+	qv["meta"] = "filter_count"
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	var respBody struct {
+		Meta struct {
+			FilterCount json.Number `json:"filter_count"`
+		} `json:"meta"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return 0, fmt.Errorf("execute count request: %w", err)
+	}
+	count, err := respBody.Meta.FilterCount.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("parse filter count: %w", err)
+	}
+	return int(count), nil
+}
+
+// permutation returns shuffled indices [0, n)
+func (d API[R, W, PK]) permutation(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	r := d.rand()
+	for i := n - 1; i > 0; i-- {
+		j := int(r.Int63n(int64(i + 1)))
+		p[i], p[j] = p[j], p[i]
+	}
+	return p
+}
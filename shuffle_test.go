@@ -0,0 +1,60 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsShuffled(t *testing.T) {
+	const total = 25
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		if qv.Get("meta") == "filter_count" {
+			assert.Equal(t, "0", qv.Get("limit"))
+			_, _ = fmt.Fprintf(w, `{"data":[],"meta":{"filter_count":%d}}`, total)
+			return
+		}
+		limit, _ := strconv.Atoi(qv.Get("limit"))
+		offset, _ := strconv.Atoi(qv.Get("offset"))
+		_, _ = w.Write([]byte(`{"data":[`))
+		for i := offset; i < offset+limit && i < total; i++ {
+			if i > offset {
+				_, _ = w.Write([]byte(","))
+			}
+			_, _ = fmt.Fprintf(w, `{"id":%d}`, i)
+		}
+		_, _ = w.Write([]byte(`]}`))
+	}))
+	api.Rand = rand.New(rand.NewSource(1))
+
+	var ids []int
+	err := api.ItemsShuffled(context.Background(), SortAsc("id"), 10, func(f FruitR) error {
+		ids = append(ids, f.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, total)
+	seen := map[int]bool{}
+	sorted := true
+	for i, id := range ids {
+		seen[id] = true
+		sorted = sorted && id == i
+	}
+	assert.Len(t, seen, total)
+	assert.False(t, sorted)
+
+	var n int
+	err = api.ItemsShuffled(context.Background(), None(), 10, func(FruitR) error {
+		n++
+		return ErrStopIteration
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,51 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AuthProvider is an SSO provider configured on the server, Driver is e.g. oauth2, openid or ldap
+type AuthProvider struct {
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+	Icon   string `json:"icon"`
+}
+
+// AuthProviders lists SSO providers of the instance, it's supported only by v9
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#list-auth-providers
+func (d API[R, W, PK]) AuthProviders(ctx context.Context) ([]AuthProvider, error) {
+	req := request{
+		ctx,
+		http.MethodGet,
+		d.baseURL() + "/auth",
+		nil,
+		nil,
+	}
+	var respBody struct {
+		Data []AuthProvider `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("execute auth providers request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// SSOLoginURL returns the url starting the login with provider, users are sent back to redirect
+// after they are authenticated. The redirect url has to be allowed by AUTH_<PROVIDER>_REDIRECT_ALLOW_LIST
+// or PUBLIC_URL of the server.
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/authentication.html#login-using-sso-providers
+func (d API[R, W, PK]) SSOLoginURL(provider, redirect string) string {
+	u := d.baseURL() + "/auth/login/" + url.PathEscape(provider)
+	if redirect == "" {
+		return u
+	}
+	return u + "?" + url.Values{"redirect": {redirect}}.Encode()
+}
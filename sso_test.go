@@ -0,0 +1,29 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthProviders(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/auth", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":[{"name":"google","driver":"oauth2","icon":"google"},{"name":"keycloak","driver":"openid","icon":null}],"disableDefault":false}`))
+	}))
+	api.Version = V9
+
+	providers, err := api.AuthProviders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []AuthProvider{{"google", "oauth2", "google"}, {"keycloak", "openid", ""}}, providers)
+}
+
+func TestSSOLoginURL(t *testing.T) {
+	api := API[FruitR, FruitW, int]{Scheme: "https", Host: "cms.example.com"}
+	assert.Equal(t, "https://cms.example.com/auth/login/google?redirect=https%3A%2F%2Fexample.com%2Fcallback%3Fnext%3D%2F",
+		api.SSOLoginURL("google", "https://example.com/callback?next=/"))
+	assert.Equal(t, "https://cms.example.com/auth/login/google", api.SSOLoginURL("google", ""))
+}
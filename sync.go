@@ -0,0 +1,120 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// system fields tracking item modifications
+const (
+	createdFieldV8 = "created_on"
+	updatedFieldV8 = "modified_on"
+	createdFieldV9 = "date_created"
+	updatedFieldV9 = "date_updated"
+)
+
+// ItemsUpdatedSince retrieves all items created or updated at or after since, sorted from the oldest change.
+// Returned cursor is the time of the latest change among the items, pass it as since
+// of the next call to get only newer changes. When there are no items the cursor equals since.
+// Items of a single change time keep the order of q.
+//
+// The cursor is valid only when all changed items are read, so q can't have a limit, offset or page
+// and those of DefaultQuery are ignored. Items changed exactly at the cursor time are returned again
+// by the next call, so changes made in the same second as the previous call are never missed.
+// The collection needs the system fields for tracking changes:
+// created_on and modified_on for v8, date_created and date_updated for v9.
+func (d API[R, W, PK]) ItemsUpdatedSince(ctx context.Context, since time.Time, q Query) ([]R, time.Time, error) {
+	if q.limit != nil || q.offset != nil || q.page != nil {
+		return nil, since, errors.New("items updated since can't be paginated, all changes since the cursor are returned")
+	}
+	createdField, updatedField := createdFieldV9, updatedFieldV9
+	ts := since.UTC().Format(time.RFC3339)
+	if d.Version == V8 {
+		createdField, updatedField = createdFieldV8, updatedFieldV8
+		ts = since.UTC().Format(datetimeFormat)
+		q = q.Gte(updatedField, ts)
+	} else {
+		// date_updated stays null until the item is updated for the first time
+		q = q.Or(Gte(updatedField, ts), Null(updatedField).Gte(createdField, ts))
+	}
+
+	req, err := d.itemsRequest(ctx, q.Limit(AllItems))
+	if err != nil {
+		return nil, since, err
+	}
+	delete(req.qv, "offset")
+	delete(req.qv, "page")
+	req.qv["limit"] = fmt.Sprint(AllItems)
+	req.qv["fields"] += "," + createdField + "," + updatedField
+	var respBody json.RawMessage
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return nil, since, fmt.Errorf("execute items updated since request: %w", err)
+	}
+
+	var items itemsEnvelope[R]
+	if err := json.Unmarshal(respBody, &items); err != nil {
+		return nil, since, fmt.Errorf("decoding json response: %w", err)
+	}
+	// the envelope is decoded here, so hooks aren't applied by executeRequest
+	if err := d.postProcess(ctx, &items); err != nil {
+		return nil, since, err
+	}
+	var changes struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &changes); err != nil {
+		return nil, since, fmt.Errorf("decoding json response: %w", err)
+	}
+	if len(changes.Data) != len(items.Data) {
+		return nil, since, errors.New("decoding json response: items don't match their changes")
+	}
+
+	// the server can't sort by the change time, date_updated is null for items which were never updated
+	changed := make([]time.Time, len(changes.Data))
+	for i, c := range changes.Data {
+		var s string
+		_ = json.Unmarshal(c[updatedField], &s)
+		if s == "" {
+			_ = json.Unmarshal(c[createdField], &s)
+		}
+		if s == "" {
+			continue
+		}
+		t, err := parseTimestamp(s)
+		if err != nil {
+			return nil, since, fmt.Errorf("parse change time: %w", err)
+		}
+		changed[i] = t
+	}
+	order := make([]int, len(items.Data))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return changed[order[i]].Before(changed[order[j]]) })
+
+	cursor := since
+	sorted := make([]R, len(order))
+	for i, k := range order {
+		sorted[i] = items.Data[k]
+		if changed[k].After(cursor) {
+			cursor = changed[k]
+		}
+	}
+	return sorted, cursor, nil
+}
+
+// parseTimestamp parses timestamps in formats used by Directus v8 and v9
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(datetimeFormat, s)
+}
@@ -0,0 +1,47 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsUpdatedSince(t *testing.T) {
+	since := time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC)
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		assert.Equal(t, "2022-05-05T10:00:00Z", qv.Get("filter[_and][0][_or][0][date_updated][_gte]"))
+		assert.Equal(t, "true", qv.Get("filter[_and][0][_or][1][date_updated][_null]"))
+		assert.Equal(t, "2022-05-05T10:00:00Z", qv.Get("filter[_and][0][_or][1][date_created][_gte]"))
+		assert.Equal(t, "-1", qv.Get("limit"))
+		assert.Empty(t, qv.Get("offset"))
+		assert.Contains(t, qv.Get("fields"), ",date_created,date_updated")
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":1,"name":"kiwi","date_created":"2022-05-05T11:00:00.000Z","date_updated":null},
+			{"id":2,"name":"plum","date_created":"2022-01-01T00:00:00.000Z","date_updated":"2022-05-05T10:30:00.000Z"}
+		]}`))
+	}))
+	api.Namespace = ""
+	api.Version = V9
+	api.DefaultQuery = Offset(20)
+
+	fruits, cursor, err := api.ItemsUpdatedSince(context.Background(), since, None())
+	require.NoError(t, err)
+	require.Len(t, fruits, 2)
+	// plum was updated before kiwi was created
+	assert.Equal(t, "plum", fruits[0].Name)
+	assert.Equal(t, "kiwi", fruits[1].Name)
+	assert.Equal(t, time.Date(2022, 5, 5, 11, 0, 0, 0, time.UTC), cursor)
+
+	// a limited page would move the cursor past changes left out of it
+	_, cursor, err = api.ItemsUpdatedSince(context.Background(), since, Limit(1))
+	assert.Error(t, err)
+	assert.Equal(t, since, cursor)
+
+	_, _, err = api.ItemsUpdatedSince(context.Background(), since, Eq("", "x"))
+	assert.Error(t, err, "queries are validated")
+}
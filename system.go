@@ -0,0 +1,206 @@
+package directusapi
+
+// systemPrefix is a prefix of names of Directus system collections
+const systemPrefix = "directus_"
+
+// System collections, requests of an API for them are sent to their own endpoints instead of /items
+const (
+	CollectionUsers       = "directus_users"
+	CollectionRoles       = "directus_roles"
+	CollectionFiles       = "directus_files"
+	CollectionFolders     = "directus_folders"
+	CollectionPermissions = "directus_permissions"
+	CollectionPresets     = "directus_presets"
+	CollectionActivity    = "directus_activity"
+	CollectionRevisions   = "directus_revisions"
+	CollectionWebhooks    = "directus_webhooks"
+)
+
+// Ready-made clients of system collections, models follow Directus v9 and timestamps are kept
+// as returned by the server. Activity and revisions are read only.
+type (
+	UsersAPI       = API[User, UserW, string]
+	RolesAPI       = API[Role, RoleW, string]
+	FilesAPI       = API[File, FileW, string]
+	FoldersAPI     = API[Folder, FolderW, string]
+	PermissionsAPI = API[Permission, PermissionW, int]
+	PresetsAPI     = API[Preset, PresetW, int]
+	ActivityAPI    = API[Activity, Activity, int]
+	RevisionsAPI   = API[Revision, Revision, int]
+	WebhooksAPI    = API[Webhook, WebhookW, int]
+)
+
+// User is a read model of directus_users
+type User struct {
+	ID          string `json:"id"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Email       string `json:"email"`
+	Location    string `json:"location"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Avatar      string `json:"avatar"`
+	Language    string `json:"language"`
+	Status      string `json:"status"`
+	Role        string `json:"role"`
+	LastAccess  string `json:"last_access"`
+}
+
+// UserW is a write model of directus_users, empty fields are not sent
+type UserW struct {
+	FirstName   string `json:"first_name,omitempty"`
+	LastName    string `json:"last_name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Password    string `json:"password,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Avatar      string `json:"avatar,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Role        string `json:"role,omitempty"`
+}
+
+// Role is a read model of directus_roles
+type Role struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Icon        string   `json:"icon"`
+	Description string   `json:"description"`
+	IPAccess    []string `json:"ip_access"`
+	EnforceTFA  bool     `json:"enforce_tfa"`
+	AdminAccess bool     `json:"admin_access"`
+	AppAccess   bool     `json:"app_access"`
+}
+
+// RoleW is a write model of directus_roles, empty fields are not sent except EnforceTFA, AdminAccess
+// and AppAccess which are always sent, so Set of a partial role turns them off.
+// Use Update with partials to change only some fields of a role.
+type RoleW struct {
+	Name        string   `json:"name,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Description string   `json:"description,omitempty"`
+	IPAccess    []string `json:"ip_access,omitempty"`
+	EnforceTFA  bool     `json:"enforce_tfa"`
+	AdminAccess bool     `json:"admin_access"`
+	AppAccess   bool     `json:"app_access"`
+}
+
+// FileW is a write model of metadata of directus_files, empty fields are not sent
+type FileW struct {
+	Title            string `json:"title,omitempty"`
+	Description      string `json:"description,omitempty"`
+	FilenameDownload string `json:"filename_download,omitempty"`
+	Folder           string `json:"folder,omitempty"`
+}
+
+// Folder is a read model of directus_folders
+type Folder struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+}
+
+// FolderW is a write model of directus_folders
+type FolderW struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// Permission is a read model of directus_permissions
+type Permission struct {
+	ID          int            `json:"id"`
+	Role        string         `json:"role"`
+	Collection  string         `json:"collection"`
+	Action      string         `json:"action"`
+	Permissions map[string]any `json:"permissions"`
+	Validation  map[string]any `json:"validation"`
+	Presets     map[string]any `json:"presets"`
+	Fields      []string       `json:"fields"`
+}
+
+// PermissionW is a write model of directus_permissions, an unset Role is the public role
+type PermissionW struct {
+	Role        Optional[string] `json:"role"`
+	Collection  string           `json:"collection"`
+	Action      string           `json:"action"`
+	Permissions map[string]any   `json:"permissions,omitempty"`
+	Validation  map[string]any   `json:"validation,omitempty"`
+	Presets     map[string]any   `json:"presets,omitempty"`
+	Fields      []string         `json:"fields,omitempty"`
+}
+
+// Preset is a read model of directus_presets, bookmarks have a name in Bookmark
+type Preset struct {
+	ID            int            `json:"id"`
+	Bookmark      string         `json:"bookmark"`
+	User          string         `json:"user"`
+	Role          string         `json:"role"`
+	Collection    string         `json:"collection"`
+	Search        string         `json:"search"`
+	Layout        string         `json:"layout"`
+	LayoutQuery   map[string]any `json:"layout_query"`
+	LayoutOptions map[string]any `json:"layout_options"`
+	Filter        map[string]any `json:"filter"`
+}
+
+// PresetW is a write model of directus_presets, empty fields are not sent
+type PresetW struct {
+	Bookmark      string         `json:"bookmark,omitempty"`
+	User          string         `json:"user,omitempty"`
+	Role          string         `json:"role,omitempty"`
+	Collection    string         `json:"collection,omitempty"`
+	Search        string         `json:"search,omitempty"`
+	Layout        string         `json:"layout,omitempty"`
+	LayoutQuery   map[string]any `json:"layout_query,omitempty"`
+	LayoutOptions map[string]any `json:"layout_options,omitempty"`
+	Filter        map[string]any `json:"filter,omitempty"`
+}
+
+// Activity is a read model of directus_activity
+type Activity struct {
+	ID         int    `json:"id"`
+	Action     string `json:"action"`
+	User       string `json:"user"`
+	Timestamp  string `json:"timestamp"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	Collection string `json:"collection"`
+	Item       string `json:"item"`
+	Comment    string `json:"comment"`
+}
+
+// Revision is a read model of directus_revisions, Data is the item after the change
+// and Delta holds only the changed fields
+type Revision struct {
+	ID         int            `json:"id"`
+	Activity   int            `json:"activity"`
+	Collection string         `json:"collection"`
+	Item       string         `json:"item"`
+	Data       map[string]any `json:"data"`
+	Delta      map[string]any `json:"delta"`
+	Parent     int            `json:"parent"`
+}
+
+// Webhook is a read model of directus_webhooks
+type Webhook struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Method      string   `json:"method"`
+	URL         string   `json:"url"`
+	Status      string   `json:"status"`
+	Data        bool     `json:"data"`
+	Actions     []string `json:"actions"`
+	Collections []string `json:"collections"`
+}
+
+// WebhookW is a write model of directus_webhooks
+type WebhookW struct {
+	Name        string   `json:"name"`
+	Method      string   `json:"method"`
+	URL         string   `json:"url"`
+	Status      string   `json:"status"`
+	Data        bool     `json:"data"`
+	Actions     []string `json:"actions"`
+	Collections []string `json:"collections"`
+}
@@ -0,0 +1,77 @@
+package directusapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemCollectionURL(t *testing.T) {
+	var paths []string
+	base := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{"id":"8a2c","email":"admin@example.com"}}`))
+	}))
+	users := UsersAPI{
+		Scheme:         base.Scheme,
+		Host:           base.Host,
+		CollectionName: CollectionUsers,
+		HTTPClient:     base.HTTPClient,
+		Version:        V9,
+	}
+
+	u, err := users.GetByID(context.Background(), "8a2c")
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", u.Email)
+	assert.Equal(t, []string{"/users/8a2c"}, paths)
+
+	presets := PresetsAPI{Scheme: "http", Host: "localhost", Namespace: "_", CollectionName: CollectionPresets}
+	assert.Equal(t, "http://localhost/_/collection_presets", presets.itemsURL())
+	presets.Version = V9
+	assert.Equal(t, "http://localhost/_/presets", presets.itemsURL())
+	presets.CollectionName = "fruits"
+	assert.Equal(t, "http://localhost/_/items/fruits", presets.itemsURL())
+}
+
+func TestSystemModelsFields(t *testing.T) {
+	assert.NotPanics(t, func() {
+		(&UsersAPI{}).jsonFieldsR()
+		(&RolesAPI{}).jsonFieldsR()
+		(&FilesAPI{}).jsonFieldsR()
+		(&FoldersAPI{}).jsonFieldsR()
+		(&PermissionsAPI{}).jsonFieldsR()
+		(&PresetsAPI{}).jsonFieldsR()
+		(&ActivityAPI{}).jsonFieldsR()
+		(&RevisionsAPI{}).jsonFieldsR()
+		(&WebhooksAPI{}).jsonFieldsR()
+	})
+}
+
+func TestRoleWPartial(t *testing.T) {
+	var bodies []string
+	base := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		_, _ = w.Write([]byte(`{"data":{"id":"7c1f","name":"editor"}}`))
+	}))
+	roles := RolesAPI{
+		Scheme:         base.Scheme,
+		Host:           base.Host,
+		CollectionName: CollectionRoles,
+		HTTPClient:     base.HTTPClient,
+		Version:        V9,
+	}
+
+	_, err := roles.Set(context.Background(), "7c1f", RoleW{Name: "editor"})
+	require.NoError(t, err)
+	_, err = roles.Update(context.Background(), "7c1f", map[string]any{"name": "editor"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`{"name":"editor","enforce_tfa":false,"admin_access":false,"app_access":false}`,
+		`{"name":"editor"}`,
+	}, bodies)
+}
@@ -0,0 +1,185 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNoTenant is returned by scoped APIs when the context has no tenant and isn't Unscoped
+	ErrNoTenant = errors.New("directusapi: no tenant in context")
+	// ErrOutOfScope is returned when an item doesn't belong to the tenant of the context
+	ErrOutOfScope = errors.New("directusapi: item is out of the tenant scope")
+)
+
+// TenantScope makes every read and write of an API instance scoped to the tenant taken from the context.
+// Reads are filtered by the tenant field, writes get the tenant field set in their payload
+// and updates or deletes of items of other tenants fail with ErrOutOfScope.
+type TenantScope struct {
+	// Field holds the tenant of an item, e.g. organization
+	Field string
+	// PrimaryKey is the primary key field of the collection, defaults to id
+	PrimaryKey string
+}
+
+type tenantCtxKey struct{}
+
+type unscopedCtxKey struct{}
+
+// WithTenant returns a context scoping requests of scoped APIs to tenant
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+// Unscoped returns a context which disables the tenant scope, it's an explicit
+// escape hatch for maintenance jobs working across tenants
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedCtxKey{}, true)
+}
+
+// tenant returns the tenant of ctx, scoped is false when no scope applies
+func (d API[R, W, PK]) tenant(ctx context.Context) (tenant any, scoped bool, err error) {
+	if d.Tenant == nil {
+		return nil, false, nil
+	}
+	if unscoped, _ := ctx.Value(unscopedCtxKey{}).(bool); unscoped {
+		return nil, false, nil
+	}
+	tenant = ctx.Value(tenantCtxKey{})
+	if tenant == nil {
+		return nil, false, ErrNoTenant
+	}
+	return tenant, true, nil
+}
+
+// scopeQuery forces the tenant filter into q
+func (d API[R, W, PK]) scopeQuery(ctx context.Context, q Query) (Query, error) {
+	tenant, scoped, err := d.tenant(ctx)
+	if err != nil || !scoped {
+		return q, err
+	}
+	return q.withDefaults(None()).Eq(d.Tenant.Field, fmt.Sprint(tenant)), nil
+}
+
+// scopeBody sets the tenant field of a write payload
+func (d API[R, W, PK]) scopeBody(ctx context.Context, body any) (any, error) {
+	tenant, scoped, err := d.tenant(ctx)
+	if err != nil || !scoped {
+		return body, err
+	}
+	return setBodyField(body, d.Tenant.Field, tenant, true)
+}
+
+// setBodyField sets field of a JSON object payload, an existing value is kept unless overwrite is set,
+// null and zero values of ids are not considered set
+func setBodyField(body any, field string, value any, overwrite bool) (any, error) {
+	raw, ok := body.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("marshal body: %w", err)
+		}
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("body is not an object: %w", err)
+	}
+	if v, ok := fields[field]; ok && !overwrite && !zeroID(v) {
+		return body, nil
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", field, err)
+	}
+	fields[field] = v
+	if raw, err = json.Marshal(fields); err != nil {
+		return nil, fmt.Errorf("marshal body: %w", err)
+	}
+	return json.RawMessage(raw), nil
+}
+
+// checkScope verifies that the item belongs to the tenant of ctx
+func (d API[R, W, PK]) checkScope(ctx context.Context, id PK) error {
+	_, scoped, err := d.tenant(ctx)
+	if err != nil || !scoped {
+		return err
+	}
+	pk, err := formatPK(id)
+	if err != nil {
+		return err
+	}
+	q, err := d.scopeQuery(ctx, Eq(d.tenantPrimaryKey(), pk))
+	if err != nil {
+		return err
+	}
+	u, qv := d.itemsRequestParams(q)
+	qv["fields"] = d.tenantPrimaryKey()
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	var respBody struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := d.executeRequest(req, http.StatusOK, &respBody); err != nil {
+		return fmt.Errorf("execute scope check request: %w", err)
+	}
+	if len(respBody.Data) == 0 {
+		return ErrOutOfScope
+	}
+	return nil
+}
+
+func (d API[R, W, PK]) tenantPrimaryKey() string {
+	if d.Tenant.PrimaryKey == "" {
+		return "id"
+	}
+	return d.Tenant.PrimaryKey
+}
+
+// getScoped reads an item by id through the items endpoint filtered by the tenant,
+// params override the params of the items request
+func (d API[R, W, PK]) getScoped(ctx context.Context, id PK, params map[string]string) (R, error) {
+	var empty R
+	pk, err := formatPK(id)
+	if err != nil {
+		return empty, err
+	}
+	q, err := d.scopeQuery(ctx, Eq(d.tenantPrimaryKey(), pk).Limit(1))
+	if err != nil {
+		return empty, err
+	}
+	u, qv := d.itemsRequestParams(q)
+	for k, v := range params {
+		qv[k] = v
+	}
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	var respBody itemsEnvelope[R]
+	if err := d.executeRequest(req, http.StatusOK, &respBody); err != nil {
+		return empty, fmt.Errorf("execute get by id request: %w", err)
+	}
+	if len(respBody.Data) == 0 {
+		return empty, ErrOutOfScope
+	}
+	return respBody.Data[0], nil
+}
+
+func zeroID(v json.RawMessage) bool {
+	switch string(v) {
+	case "null", `""`, "0":
+		return true
+	}
+	return false
+}
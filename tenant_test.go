@@ -0,0 +1,75 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantScope(t *testing.T) {
+	var bodies []map[string]any
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qv := r.URL.Query()
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "acme", qv.Get("filter[status][eq]"))
+			if qv.Get("filter[id][eq]") == "2" {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"status":"acme"}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			bodies = append(bodies, body)
+			_, _ = w.Write([]byte(`{"data":{"id":1,"status":"acme"}}`))
+		}
+	}))
+	api.Tenant = &TenantScope{Field: "status"}
+	ctx := WithTenant(context.Background(), "acme")
+
+	_, err := api.Items(context.Background(), None())
+	assert.ErrorIs(t, err, ErrNoTenant)
+
+	items, err := api.Items(ctx, Eq("status", "other"))
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	item, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, item.ID)
+	_, err = api.GetByID(ctx, 2)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+
+	_, err = api.Insert(ctx, FruitW{Name: "kiwi", Status: "other"})
+	require.NoError(t, err)
+	_, err = api.Update(ctx, 1, map[string]any{"name": "lime"})
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "acme", bodies[0]["status"])
+	assert.Equal(t, "kiwi", bodies[0]["name"])
+	assert.Equal(t, map[string]any{"name": "lime", "status": "acme"}, bodies[1])
+
+	_, err = api.Update(ctx, 2, map[string]any{"name": "lime"})
+	assert.ErrorIs(t, err, ErrOutOfScope)
+	assert.ErrorIs(t, api.Delete(ctx, 2), ErrOutOfScope)
+	assert.NoError(t, api.Delete(ctx, 1))
+}
+
+func TestUnscoped(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, r.URL.Query().Has("filter[status][eq]"))
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	api.Tenant = &TenantScope{Field: "status"}
+
+	ctx := Unscoped(WithTenant(context.Background(), "acme"))
+	_, err := api.Items(ctx, None())
+	assert.NoError(t, err)
+}
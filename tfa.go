@@ -0,0 +1,76 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TFASecret is a generated secret of two-factor authentication,
+// OTPAuthURL is usually shown as a QR code to be scanned by an authenticator app
+type TFASecret struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+// GenerateTFA generates a two-factor authentication secret for the user of the current token,
+// it isn't used until it's confirmed by EnableTFA
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#generate-two-factor-authentication-secret
+func (d API[R, W, PK]) GenerateTFA(ctx context.Context, password string) (TFASecret, error) {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/users/me/tfa/generate",
+		nil,
+		map[string]string{"password": password},
+	}
+	var respBody struct {
+		Data TFASecret `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return TFASecret{}, fmt.Errorf("execute generate tfa request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// EnableTFA enables two-factor authentication of the user of the current token,
+// otp has to be generated with the secret returned by GenerateTFA
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#enable-two-factor-authentication
+func (d API[R, W, PK]) EnableTFA(ctx context.Context, secret, otp string) error {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/users/me/tfa/enable",
+		nil,
+		map[string]string{"secret": secret, "otp": otp},
+	}
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute enable tfa request: %w", err)
+	}
+	return nil
+}
+
+// DisableTFA disables two-factor authentication of the user of the current token
+//
+// Related Directus reference:
+// https://docs.directus.io/reference/system/users.html#disable-two-factor-authentication
+func (d API[R, W, PK]) DisableTFA(ctx context.Context, otp string) error {
+	req := request{
+		ctx,
+		http.MethodPost,
+		d.baseURL() + "/users/me/tfa/disable",
+		nil,
+		map[string]string{"otp": otp},
+	}
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute disable tfa request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTFA(t *testing.T) {
+	bodies := map[string]map[string]string{}
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies[r.URL.Path] = body
+		switch r.URL.Path {
+		case "/_/users/me/tfa/generate":
+			_, _ = w.Write([]byte(`{"data":{"secret":"JBSWY3DPEHPK3PXP","otpauth_url":"otpauth://totp/Directus:admin?secret=JBSWY3DPEHPK3PXP"}}`))
+		case "/_/auth/login":
+			_, _ = w.Write([]byte(`{"data":{"access_token":"access","refresh_token":"refresh","expires":900000}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	api.Version = V9
+	ctx := context.Background()
+
+	secret, err := api.GenerateTFA(ctx, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", secret.Secret)
+	assert.Contains(t, secret.OTPAuthURL, "otpauth://")
+
+	require.NoError(t, api.EnableTFA(ctx, secret.Secret, "123456"))
+	_, err = api.Login(ctx, "admin@example.com", "secret", "654321")
+	require.NoError(t, err)
+	require.NoError(t, api.DisableTFA(ctx, "111111"))
+
+	assert.Equal(t, map[string]map[string]string{
+		"/_/users/me/tfa/generate": {"password": "secret"},
+		"/_/users/me/tfa/enable":   {"secret": "JBSWY3DPEHPK3PXP", "otp": "123456"},
+		"/_/auth/login":            {"email": "admin@example.com", "password": "secret", "otp": "654321", "mode": "json"},
+		"/_/users/me/tfa/disable":  {"otp": "111111"},
+	}, bodies)
+}
@@ -0,0 +1,58 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antoniobuconjic/directusapi/tokenstore"
+)
+
+// TokenStore persists tokens between process restarts, see package tokenstore for its implementations
+type TokenStore = tokenstore.Store
+
+// ErrNoTokens is returned by TokenStore.Load when no tokens were saved yet or they were cleared by LogoutStored
+var ErrNoTokens = tokenstore.ErrNoTokens
+
+// FileTokenStore stores tokens in an encrypted file.
+//
+// Deprecated: use tokenstore.FileStore.
+type FileTokenStore = tokenstore.FileStore
+
+// Keyring is a secret storage of the operating system.
+//
+// Deprecated: use tokenstore.Keyring.
+type Keyring = tokenstore.Keyring
+
+// KeyringTokenStore stores tokens in a Keyring.
+//
+// Deprecated: use tokenstore.KeyringStore.
+type KeyringTokenStore = tokenstore.KeyringStore
+
+// NewStoredAuthManager creates a manager of tokens loaded from store, refreshed tokens are saved back to it.
+// It returns ErrNoTokens when the store is empty, save tokens of Login first.
+func (d API[R, W, PK]) NewStoredAuthManager(ctx context.Context, store TokenStore) (*AuthManager, error) {
+	tokens, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load tokens: %w", err)
+	}
+	m := d.NewAuthManager(tokens)
+	m.Store = store
+	return m, nil
+}
+
+// LogoutStored invalidates the session of the refresh token saved in store and clears the store,
+// so processes sharing it have to log in again, e.g. for logout of the CLI or a daemon.
+// It's supported only by v9. Sessions of other devices stay valid, Directus doesn't expose them through the API.
+func (d API[R, W, PK]) LogoutStored(ctx context.Context, store TokenStore) error {
+	tokens, err := store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load tokens: %w", err)
+	}
+	if err := d.Logout(ctx, tokens.RefreshToken); err != nil {
+		return err
+	}
+	if err := store.Save(ctx, AuthTokens{}); err != nil {
+		return fmt.Errorf("clear tokens: %w", err)
+	}
+	return nil
+}
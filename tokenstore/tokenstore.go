@@ -0,0 +1,165 @@
+// Package tokenstore persists tokens of Directus sessions between process restarts,
+// so daemons and CLIs resume their session by the refresh token instead of authenticating with a password.
+package tokenstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNoTokens is returned by Store.Load when no tokens were saved yet or they were cleared,
+// e.g. by LogoutStored of directusapi
+var ErrNoTokens = errors.New("tokenstore: no stored tokens")
+
+// Tokens are tokens of an authenticated session
+type Tokens struct {
+	AccessToken string
+	// RefreshToken is used to renew the access token,
+	// Directus v8 renews the access token itself so both are the same
+	RefreshToken string
+	// Expires is an expiration of the access token, it's zero when unknown
+	Expires time.Time
+}
+
+// Store persists tokens between process restarts, saving empty tokens clears it
+type Store interface {
+	Load(ctx context.Context) (Tokens, error)
+	Save(ctx context.Context, tokens Tokens) error
+}
+
+// storedTokens is the serialized form of Tokens
+type storedTokens struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expires      time.Time `json:"expires"`
+}
+
+func marshalTokens(t Tokens) ([]byte, error) {
+	return json.Marshal(storedTokens{t.AccessToken, t.RefreshToken, t.Expires})
+}
+
+func unmarshalTokens(data []byte) (Tokens, error) {
+	var t storedTokens
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Tokens{}, fmt.Errorf("decode tokens: %w", err)
+	}
+	if t.AccessToken == "" && t.RefreshToken == "" {
+		// cleared by saving empty tokens
+		return Tokens{}, ErrNoTokens
+	}
+	return Tokens{t.AccessToken, t.RefreshToken, t.Expires}, nil
+}
+
+// FileStore stores tokens in a file encrypted by AES-GCM, the file is readable only by its owner
+type FileStore struct {
+	Path string
+	// Key is an AES key of 16, 24 or 32 bytes, e.g. from a secret manager or an environment variable
+	Key []byte
+}
+
+func (s FileStore) Load(context.Context) (Tokens, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Tokens{}, ErrNoTokens
+	}
+	if err != nil {
+		return Tokens{}, fmt.Errorf("read token file: %w", err)
+	}
+	aead, err := s.aead()
+	if err != nil {
+		return Tokens{}, err
+	}
+	if len(data) < aead.NonceSize() {
+		return Tokens{}, errors.New("token file is corrupted")
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("decrypt token file: %w", err)
+	}
+	return unmarshalTokens(plain)
+}
+
+// Save replaces the file atomically, so a crash never leaves a partially written file behind
+func (s FileStore) Save(_ context.Context, tokens Tokens) error {
+	plain, err := marshalTokens(tokens)
+	if err != nil {
+		return err
+	}
+	aead, err := s.aead()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	data := aead.Seal(nonce, nonce, plain, nil)
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("create token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("replace token file: %w", err)
+	}
+	return nil
+}
+
+func (s FileStore) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.Key)
+	if err != nil {
+		return nil, fmt.Errorf("token file key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Keyring is a secret storage of the operating system, e.g. an adapter of github.com/zalando/go-keyring.
+// Get has to return ErrNoTokens when the secret doesn't exist.
+type Keyring interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+}
+
+// KeyringStore stores tokens in a Keyring as a secret of Service and User
+type KeyringStore struct {
+	Keyring Keyring
+	Service string
+	User    string
+}
+
+func (s KeyringStore) Load(context.Context) (Tokens, error) {
+	secret, err := s.Keyring.Get(s.Service, s.User)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("read keyring: %w", err)
+	}
+	return unmarshalTokens([]byte(secret))
+}
+
+func (s KeyringStore) Save(_ context.Context, tokens Tokens) error {
+	data, err := marshalTokens(tokens)
+	if err != nil {
+		return err
+	}
+	if err := s.Keyring.Set(s.Service, s.User, string(data)); err != nil {
+		return fmt.Errorf("write keyring: %w", err)
+	}
+	return nil
+}
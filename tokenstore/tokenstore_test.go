@@ -0,0 +1,72 @@
+package tokenstore
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := FileStore{filepath.Join(t.TempDir(), "tokens"), bytes.Repeat([]byte("k"), 32)}
+
+	_, err := store.Load(ctx)
+	require.ErrorIs(t, err, ErrNoTokens)
+
+	tokens := Tokens{"access", "refresh", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	require.NoError(t, store.Save(ctx, tokens))
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tokens, loaded)
+
+	data, err := os.ReadFile(store.Path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "refresh")
+	info, err := os.Stat(store.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	store.Key = bytes.Repeat([]byte("x"), 32)
+	_, err = store.Load(ctx)
+	assert.ErrorContains(t, err, "decrypt token file")
+}
+
+type mapKeyring map[string]string
+
+func (k mapKeyring) Get(service, user string) (string, error) {
+	s, ok := k[service+"/"+user]
+	if !ok {
+		return "", ErrNoTokens
+	}
+	return s, nil
+}
+
+func (k mapKeyring) Set(service, user, secret string) error {
+	k[service+"/"+user] = secret
+	return nil
+}
+
+func TestKeyringStore(t *testing.T) {
+	ctx := context.Background()
+	store := KeyringStore{mapKeyring{}, "directus", "daemon"}
+
+	_, err := store.Load(ctx)
+	require.ErrorIs(t, err, ErrNoTokens)
+
+	tokens := Tokens{"access", "refresh", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	require.NoError(t, store.Save(ctx, tokens))
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tokens, loaded)
+
+	// empty tokens clear the store
+	require.NoError(t, store.Save(ctx, Tokens{}))
+	_, err = store.Load(ctx)
+	require.ErrorIs(t, err, ErrNoTokens)
+}
@@ -0,0 +1,68 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antoniobuconjic/directusapi/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredAuthManager(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_/auth/refresh":
+			fmt.Fprint(w, `{"data":{"access_token":"access-1","refresh_token":"refresh-1","expires":900000}}`)
+		case "/_/items/fruits/1":
+			fmt.Fprintf(w, `{"data":{"id":1,"name":%q}}`, r.Header.Get("Authorization"))
+		}
+	}))
+	api.Version = V9
+	ctx := context.Background()
+	store := tokenstore.FileStore{Path: filepath.Join(t.TempDir(), "tokens"), Key: make([]byte, 32)}
+
+	_, err := api.NewStoredAuthManager(ctx, store)
+	require.True(t, errors.Is(err, ErrNoTokens), err)
+
+	require.NoError(t, store.Save(ctx, AuthTokens{AccessToken: "access-0", RefreshToken: "refresh-0", Expires: time.Now()}))
+	api.Auth, err = api.NewStoredAuthManager(ctx, store)
+	require.NoError(t, err)
+
+	fruit, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer access-1", fruit.Name)
+	saved, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-1", saved.RefreshToken)
+}
+
+func TestLogoutStored(t *testing.T) {
+	var loggedOut []string
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/auth/logout", r.URL.Path)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		loggedOut = append(loggedOut, body["refresh_token"])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	api.Version = V9
+	ctx := context.Background()
+	store := tokenstore.FileStore{Path: filepath.Join(t.TempDir(), "tokens"), Key: make([]byte, 32)}
+
+	require.True(t, errors.Is(api.LogoutStored(ctx, store), ErrNoTokens))
+	require.NoError(t, store.Save(ctx, AuthTokens{AccessToken: "access-0", RefreshToken: "refresh-0", Expires: time.Now()}))
+	require.NoError(t, api.LogoutStored(ctx, store))
+	assert.Equal(t, []string{"refresh-0"}, loggedOut)
+
+	_, err := store.Load(ctx)
+	assert.True(t, errors.Is(err, ErrNoTokens), err)
+	_, err = api.NewStoredAuthManager(ctx, store)
+	assert.True(t, errors.Is(err, ErrNoTokens), err)
+}
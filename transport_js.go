@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package directusapi
+
+import "net/http"
+
+// FetchTransport returns a RoundTripper for browsers which sets options of the underlying fetch call.
+// Browsers don't allow setting the Cookie header, use credentials "include" instead of
+// http.Client's Jar so the browser attaches Directus session cookies itself.
+// Empty values leave the browser defaults.
+//
+// Related fetch reference:
+// https://developer.mozilla.org/en-US/docs/Web/API/fetch#parameters
+func FetchTransport(base http.RoundTripper, credentials, mode string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return fetchTransport{base, credentials, mode}
+}
+
+type fetchTransport struct {
+	base        http.RoundTripper
+	credentials string
+	mode        string
+}
+
+func (t fetchTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	if t.credentials != "" {
+		r.Header.Set("js.fetch:credentials", t.credentials)
+	}
+	if t.mode != "" {
+		r.Header.Set("js.fetch:mode", t.mode)
+	}
+	return t.base.RoundTrip(r)
+}
@@ -0,0 +1,27 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// ItemsUnion retrieves items matching any of the queries, e.g. segments enabled by feature flags,
+// without building a single query combining all of them. Items are deduplicated by their
+// primary key returned by id and keep the order of the first query returning them.
+func (d API[R, W, PK]) ItemsUnion(ctx context.Context, id func(R) PK, qs ...Query) ([]R, error) {
+	var out []R
+	seen := map[PK]bool{}
+	for i, q := range qs {
+		items, err := d.Items(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+		for _, item := range items {
+			if pk := id(item); !seen[pk] {
+				seen[pk] = true
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
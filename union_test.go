@@ -0,0 +1,35 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsUnion(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("filter[status][eq]") {
+		case "published":
+			_, _ = w.Write([]byte(`{"data":[{"id":3,"name":"kiwi"},{"id":1,"name":"apple"}]}`))
+		case "featured":
+			_, _ = w.Write([]byte(`{"data":[{"id":2,"name":"pear"},{"id":3,"name":"kiwi"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	id := func(f FruitR) int { return f.ID }
+
+	fruits, err := api.ItemsUnion(context.Background(), id, Eq("status", "published"), Eq("status", "featured"))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range fruits {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"kiwi", "apple", "pear"}, names)
+
+	_, err = api.ItemsUnion(context.Background(), id, Eq("status", "published"), Eq("status", "draft"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Validator runs business validation and sanitization of written items in one place per collection.
+// Item is called by Insert, Set and InsertMany, Partials by Create and Update. Both are optional
+// and receive the value which is going to be sent, so they can also sanitize it.
+type Validator[W any] struct {
+	Item     func(ctx context.Context, item *W) error
+	Partials func(ctx context.Context, partials map[string]any) error
+}
+
+func (v *Validator[W]) item(ctx context.Context, item *W) error {
+	if v == nil || v.Item == nil {
+		return nil
+	}
+	if err := v.Item(ctx, item); err != nil {
+		return fmt.Errorf("validate item: %w", err)
+	}
+	return nil
+}
+
+func (v *Validator[W]) partials(ctx context.Context, partials map[string]any) error {
+	if v == nil || v.Partials == nil {
+		return nil
+	}
+	if err := v.Partials(ctx, partials); err != nil {
+		return fmt.Errorf("validate partials: %w", err)
+	}
+	return nil
+}
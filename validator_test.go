@@ -0,0 +1,59 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+	var bodies []map[string]any
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &body))
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	errEmptyName := errors.New("name is required")
+	api.Validator = &Validator[FruitW]{
+		Item: func(ctx context.Context, f *FruitW) error {
+			f.Name = strings.TrimSpace(f.Name)
+			if f.Name == "" {
+				return errEmptyName
+			}
+			return nil
+		},
+		Partials: func(ctx context.Context, partials map[string]any) error {
+			if name, ok := partials["name"].(string); ok && name == "" {
+				return errEmptyName
+			}
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	_, err := api.Insert(ctx, FruitW{Name: " kiwi "})
+	require.NoError(t, err)
+	assert.Equal(t, "kiwi", bodies[0]["name"])
+
+	_, err = api.Set(ctx, 1, FruitW{Name: " "})
+	assert.ErrorIs(t, err, errEmptyName)
+	_, err = api.Create(ctx, map[string]any{"name": ""})
+	assert.ErrorIs(t, err, errEmptyName)
+	_, err = api.Update(ctx, 1, map[string]any{"name": ""})
+	assert.ErrorIs(t, err, errEmptyName)
+	_, err = api.InsertMany(ctx, []FruitW{{Name: "plum"}, {}}, BulkOptions{})
+	assert.ErrorIs(t, err, errEmptyName)
+
+	_, err = api.Update(ctx, 1, map[string]any{"weight": 2})
+	require.NoError(t, err)
+	assert.Len(t, bodies, 2, "invalid items should not be sent")
+}
@@ -0,0 +1,141 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultJobBackoff = time.Second
+
+// Job is an operation executed by Workers, Key identifies it in its result, e.g. a line of an imported file
+type Job[R any] struct {
+	Key string
+	Do  func(ctx context.Context) (R, error)
+}
+
+// JobResult is an outcome of a Job, Item is zero valued when the job failed or deleted an item
+type JobResult[R any] struct {
+	Key      string
+	Item     R
+	Err      error
+	Attempts int
+}
+
+// Workers execute jobs of a collection with bounded concurrency, e.g. items of an import job.
+// Failed jobs are retried as a whole on top of retries of single requests by API.MaxRetries.
+type Workers[R, W any, PK PrimaryKey] struct {
+	API API[R, W, PK]
+	// Concurrency is a number of jobs executed in parallel, defaults to 1
+	Concurrency int
+	// Attempts is a maximum number of attempts of a job, defaults to 1
+	Attempts int
+	// Backoff is a delay before the second attempt, it doubles for every next one, defaults to 1s
+	Backoff time.Duration
+	// Retryable decides which errors are retried, defaults to responses of the rate limiter
+	// and an unavailable server
+	Retryable func(err error) bool
+}
+
+// InsertJob returns a job inserting item
+func (w Workers[R, W, PK]) InsertJob(key string, item W) Job[R] {
+	return Job[R]{key, func(ctx context.Context) (R, error) {
+		return w.API.Insert(ctx, item)
+	}}
+}
+
+// UpdateJob returns a job updating partials of the item with id
+func (w Workers[R, W, PK]) UpdateJob(key string, id PK, partials map[string]any) Job[R] {
+	return Job[R]{key, func(ctx context.Context) (R, error) {
+		return w.API.Update(ctx, id, partials)
+	}}
+}
+
+// SetJob returns a job setting the item with id
+func (w Workers[R, W, PK]) SetJob(key string, id PK, item W) Job[R] {
+	return Job[R]{key, func(ctx context.Context) (R, error) {
+		return w.API.Set(ctx, id, item)
+	}}
+}
+
+// DeleteJob returns a job deleting the item with id
+func (w Workers[R, W, PK]) DeleteJob(key string, id PK) Job[R] {
+	return Job[R]{key, func(ctx context.Context) (R, error) {
+		var zero R
+		return zero, w.API.Delete(ctx, id)
+	}}
+}
+
+// Run executes jobs until the channel is closed and sends a result of every job to the returned channel,
+// which is closed when all jobs are done. Results are sent in order of completion.
+// When ctx is done the workers stop taking jobs and results not received yet are dropped,
+// so producers should stop sending on ctx too.
+func (w Workers[R, W, PK]) Run(ctx context.Context, jobs <-chan Job[R]) <-chan JobResult[R] {
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	results := make(chan JobResult[R], concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var job Job[R]
+				select {
+				case <-ctx.Done():
+					return
+				case j, ok := <-jobs:
+					if !ok {
+						return
+					}
+					job = j
+				}
+				select {
+				case results <- w.execute(ctx, job):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+func (w Workers[R, W, PK]) execute(ctx context.Context, job Job[R]) JobResult[R] {
+	attempts := w.Attempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+	retryable := w.Retryable
+	if retryable == nil {
+		retryable = defaultRetryableJob
+	}
+	backoff := orDefault(w.Backoff, defaultJobBackoff)
+	res := JobResult[R]{Key: job.Key}
+	for {
+		res.Attempts++
+		res.Item, res.Err = job.Do(ctx)
+		if res.Err == nil || res.Attempts >= attempts || !retryable(res.Err) {
+			return res
+		}
+		if err := w.API.sleep(ctx, backoff<<(res.Attempts-1)); err != nil {
+			return res
+		}
+	}
+}
+
+func defaultRetryableJob(err error) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode == http.StatusServiceUnavailable
+}
@@ -0,0 +1,83 @@
+package directusapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkers(t *testing.T) {
+	var inFlight, maxInFlight, calls int32
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/_/items/fruits/3":
+			// the first attempt is rejected by the rate limiter
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"id":3,"name":"plum"}}`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"data":{"id":1,"name":"kiwi"}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	workers := Workers[FruitR, FruitW, int]{API: api, Concurrency: 2, Attempts: 2, Backoff: time.Millisecond}
+
+	jobs := make(chan Job[FruitR])
+	go func() {
+		defer close(jobs)
+		jobs <- workers.InsertJob("a", FruitW{Name: "kiwi"})
+		jobs <- workers.InsertJob("b", FruitW{Name: "kiwi"})
+		jobs <- workers.UpdateJob("c", 3, map[string]any{"name": "plum"})
+		jobs <- workers.SetJob("d", 4, FruitW{Name: "fig"})
+		jobs <- workers.DeleteJob("e", 5)
+	}()
+	var results []JobResult[FruitR]
+	for res := range workers.Run(context.Background(), jobs) {
+		results = append(results, res)
+	}
+	require.Len(t, results, 5)
+	sort.Slice(results, func(i, j int) bool { return results[i].Key < results[j].Key })
+
+	assert.Equal(t, "kiwi", results[0].Item.Name)
+	assert.Equal(t, "plum", results[2].Item.Name)
+	assert.Equal(t, 2, results[2].Attempts)
+	// bad requests aren't retried
+	assert.Error(t, results[3].Err)
+	assert.Equal(t, 1, results[3].Attempts)
+	assert.NoError(t, results[4].Err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestWorkersCanceled(t *testing.T) {
+	api := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the channel of jobs is never closed, workers stop on ctx
+	results := Workers[FruitR, FruitW, int]{API: api}.Run(ctx, make(chan Job[FruitR]))
+	select {
+	case _, ok := <-results:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("results weren't closed")
+	}
+}